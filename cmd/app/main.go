@@ -1,42 +1,109 @@
 package main
 
 import (
-	"log"
-
-	"github.com/labstack/echo/v4"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/nansystem/go-ddd/internal/config"
-	"github.com/nansystem/go-ddd/internal/infrastructure/mysql"
+	"github.com/nansystem/go-ddd/internal/lifecycle"
+	"github.com/nansystem/go-ddd/internal/logging"
+	"github.com/nansystem/go-ddd/internal/metrics"
 	"github.com/nansystem/go-ddd/internal/presentation"
-	"github.com/nansystem/go-ddd/internal/usecase"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+	"github.com/nansystem/go-ddd/internal/telemetry"
 )
 
 func main() {
+	if err := run(); err != nil {
+		slog.Error("アプリケーションを終了します", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run はサーバーを起動し、終了シグナルを受け取るまでブロックします
+// os.Exitはdeferを実行しないため、終了処理が必要な処理はここで行います
+func run() error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("設定の読み込みに失敗しました: %v", err)
+		return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
 	}
 
-	db, err := mysql.NewConnection(cfg.DBConfig)
+	logger, err := logging.New(os.Stdout, cfg.Log)
 	if err != nil {
-		log.Fatalf("MySQLへの接続に失敗しました: %v", err)
+		return err
 	}
-	defer db.Close()
+	// logパッケージやライブラリの出力も同じロガーに集約する
+	slog.SetDefault(logger)
 
-	userRepository := mysql.NewUserRepository(db)
-	userService := usecase.NewUserService(userRepository)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	e := presentation.NewRouter()
-	setupRoutes(e, userService)
+	lc := lifecycle.New()
 
-	err = e.Start(":8080")
+	shutdownTracing, err := telemetry.Setup(ctx, cfg.Tracing)
 	if err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		return fmt.Errorf("トレースの設定に失敗しました: %w", err)
+	}
+	lc.OnStop(lifecycle.PhaseTelemetry, "telemetry", shutdownTracing)
+
+	store, err := openStorage(ctx, cfg, lc)
+	if err != nil {
+		// 接続後のマイグレーションに失敗した場合も、登録済みの終了処理でトレースとDBを閉じる
+		return errors.Join(err, lc.Shutdown(context.Background()))
+	}
+
+	m := metrics.New()
+	for name, db := range store.pools {
+		if err := m.RegisterDB(name, db); err != nil {
+			return errors.Join(err, lc.Shutdown(context.Background()))
+		}
 	}
-}
 
-func setupRoutes(e *echo.Echo, userService *usecase.UserService) {
-	// FIXME グループ追加のたびにmain.goが膨らんでしまわないようにする
-	userHandler := presentation.NewUserHandler(userService)
-	userHandler.SetupUserRoutes(e.Group("/users"))
+	modules, err := newModules(cfg, store, m)
+	if err != nil {
+		return errors.Join(err, lc.Shutdown(context.Background()))
+	}
+
+	e := presentation.NewRouter(logger, m, middleware.ErrorHandlerConfig{ProblemDetails: cfg.Server.ProblemDetails})
+	registry := presentation.NewModuleRegistry(modules...)
+	if err := registry.Build(e); err != nil {
+		return errors.Join(err, lc.Shutdown(context.Background()))
+	}
+	if err := registry.Start(ctx); err != nil {
+		return errors.Join(err, lc.Shutdown(context.Background()))
+	}
+	lc.OnStop(lifecycle.PhaseHTTP, "http", e.Shutdown)
+	lc.OnStop(lifecycle.PhaseWorkers, "modules", registry.Stop)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("HTTPサーバーを起動しました", "addr", cfg.Server.Addr())
+		if err := e.Start(cfg.Server.Addr()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info("終了シグナルを受信しました")
+	case startErr := <-serverErr:
+		if startErr != nil {
+			err = fmt.Errorf("サーバーの起動に失敗しました: %w", startErr)
+		}
+	}
+
+	// 処理中のリクエストの完了を待ってから、ワーカー、DBの順に停止する
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if shutdownErr := lc.Shutdown(shutdownCtx); shutdownErr != nil {
+		return errors.Join(err, shutdownErr)
+	}
+	return err
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nansystem/go-ddd/internal/config"
+	"github.com/nansystem/go-ddd/internal/health"
+	"github.com/nansystem/go-ddd/internal/infrastructure/github"
+	"github.com/nansystem/go-ddd/internal/infrastructure/idgen"
+	"github.com/nansystem/go-ddd/internal/metrics"
+	"github.com/nansystem/go-ddd/internal/presentation"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+// newModules はアプリケーションを構成するモジュールを組み立てるコンポジションルートです
+// 境界づけられたコンテキストを追加する場合は、ここにモジュールを1つ追加します
+func newModules(cfg *config.Config, store *storage, m *metrics.Metrics) ([]presentation.Module, error) {
+	healthRegistry := health.NewRegistry(cfg.Health.CacheTTL)
+	for name, checker := range store.healthCheckers {
+		healthRegistry.Register(name, checker, cfg.Health.CheckTimeout)
+	}
+	if cfg.GitHub.Token != "" {
+		httpClient := &http.Client{Transport: github.NewRateLimitTransport(http.DefaultTransport, m)}
+		githubClient := github.NewClient(httpClient, cfg.GitHub.Token,
+			github.RequestIDInterceptor(), github.TracingInterceptor(), github.MetricsInterceptor(m))
+		// GitHub APIの障害ではユーザーAPIへのトラフィックを止めないよう、参考情報として扱う
+		healthRegistry.RegisterOptional("github", github.NewHealthChecker(githubClient), cfg.Health.CheckTimeout)
+	}
+
+	idGenerator, err := idgen.New(cfg.UserIDStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("ID採番方式の設定が不正です: %w", err)
+	}
+	userService := usecase.NewTracingUserService(usecase.NewUserService(store.userRepository, idGenerator, store.unitOfWork))
+
+	return []presentation.Module{
+		presentation.NewHealthModule(healthRegistry),
+		presentation.NewUserModule(userService),
+		// 他のモジュールが登録したルートからAPIドキュメントを生成する
+		presentation.NewOpenAPIModule(),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/nansystem/go-ddd/internal/config"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/health"
+	"github.com/nansystem/go-ddd/internal/infrastructure/memory"
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql"
+	"github.com/nansystem/go-ddd/internal/infrastructure/postgres"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqlite"
+	"github.com/nansystem/go-ddd/internal/lifecycle"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+// storage は設定で選択されたDBドライバのリポジトリとUnitOfWorkです
+type storage struct {
+	userRepository user.Repository
+	unitOfWork     usecase.UnitOfWork
+	// healthCheckers はレディネスチェックに登録するDBのチェックです
+	healthCheckers map[string]health.Checker
+	// pools はメトリクスに登録するコネクションプールです
+	pools map[string]*sql.DB
+}
+
+// openStorage は設定で選択されたDBドライバで接続します
+// 接続を閉じる処理はlcに登録します
+func openStorage(ctx context.Context, cfg *config.Config, lc *lifecycle.Lifecycle) (*storage, error) {
+	switch cfg.DBConfig.Driver {
+	case config.DriverMemory:
+		repo := memory.NewUserRepository()
+		return &storage{
+			userRepository: repo,
+			unitOfWork:     memory.NewTxManager(repo),
+		}, nil
+	case config.DriverMySQL:
+		db, err := mysql.NewConnection(cfg.DBConfig.MySQL)
+		if err != nil {
+			return nil, fmt.Errorf("MySQLへの接続に失敗しました: %w", err)
+		}
+		lc.OnStop(lifecycle.PhaseDatabase, "mysql", func(_ context.Context) error {
+			return db.Close()
+		})
+		return &storage{
+			userRepository: mysql.NewUserRepository(db, cfg.DBConfig.MySQL.QueryTimeout),
+			unitOfWork:     mysql.NewTxManager(db),
+			healthCheckers: map[string]health.Checker{"mysql": sqldb.NewHealthChecker(db)},
+			pools:          map[string]*sql.DB{"mysql": db},
+		}, nil
+	case config.DriverSQLite:
+		db, err := sqlite.NewConnection(cfg.DBConfig.SQLite)
+		if err != nil {
+			return nil, fmt.Errorf("SQLiteへの接続に失敗しました: %w", err)
+		}
+		lc.OnStop(lifecycle.PhaseDatabase, "sqlite", func(_ context.Context) error {
+			return db.Close()
+		})
+		// 単一のバイナリで動かせるよう、起動時にスキーマを最新にする
+		applied, err := sqlite.Migrate(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("SQLiteのマイグレーションに失敗しました: %w", err)
+		}
+		for _, mig := range applied {
+			slog.Info("マイグレーションを適用しました", "version", mig.Version, "name", mig.Name)
+		}
+		return &storage{
+			userRepository: sqlite.NewUserRepository(db, cfg.DBConfig.SQLite.QueryTimeout),
+			unitOfWork:     sqlite.NewTxManager(db),
+			healthCheckers: map[string]health.Checker{"sqlite": sqldb.NewHealthChecker(db)},
+			pools:          map[string]*sql.DB{"sqlite": db},
+		}, nil
+	case config.DriverPostgres:
+		db, err := postgres.NewConnection(cfg.DBConfig.Postgres)
+		if err != nil {
+			return nil, fmt.Errorf("PostgreSQLへの接続に失敗しました: %w", err)
+		}
+		lc.OnStop(lifecycle.PhaseDatabase, "postgres", func(_ context.Context) error {
+			return db.Close()
+		})
+		applied, err := postgres.Migrate(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("PostgreSQLのマイグレーションに失敗しました: %w", err)
+		}
+		for _, mig := range applied {
+			slog.Info("マイグレーションを適用しました", "version", mig.Version, "name", mig.Name)
+		}
+		return &storage{
+			userRepository: postgres.NewUserRepository(db, cfg.DBConfig.Postgres.QueryTimeout),
+			unitOfWork:     postgres.NewTxManager(db),
+			healthCheckers: map[string]health.Checker{"postgres": sqldb.NewHealthChecker(db)},
+			pools:          map[string]*sql.DB{"postgres": db},
+		}, nil
+	default:
+		return nil, fmt.Errorf("未対応のDBドライバです: %s", cfg.DBConfig.Driver)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/nansystem/go-ddd/internal/config"
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql"
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql/migration"
+)
+
+const usage = `使い方: migrate [-dry-run] <command>
+
+コマンド:
+  up        未適用のマイグレーションをすべて適用します
+  down N    適用済みのマイグレーションを新しい順にN件取り消します (省略時は1件)
+  status    マイグレーションの適用状況を表示します
+  redo      最後に適用したマイグレーションを取り消して再適用します
+`
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "SQLを実行せずに表示します")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), *dryRun, flag.Args()); err != nil {
+		slog.Error("マイグレーションに失敗しました", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run はMySQLに接続してマイグレーションのコマンドを実行します
+// os.Exitはdeferを実行しないため、接続のクローズが必要な処理はここで行います
+func run(ctx context.Context, dryRun bool, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+	}
+
+	// PostgreSQLとSQLiteのマイグレーションはアプリケーションの起動時に適用される
+	if cfg.DBConfig.Driver != config.DriverMySQL {
+		return fmt.Errorf("migrateコマンドはMySQLのみ対応しています (DB_DRIVER=%s)", cfg.DBConfig.Driver)
+	}
+
+	db, err := mysql.NewConnection(cfg.DBConfig.MySQL)
+	if err != nil {
+		return fmt.Errorf("MySQLへの接続に失敗しました: %w", err)
+	}
+	defer db.Close()
+
+	migrations, err := migration.Migrations()
+	if err != nil {
+		return fmt.Errorf("マイグレーションの読み込みに失敗しました: %w", err)
+	}
+
+	migrator := migration.NewMigrator(db, migrations, os.Stdout)
+	migrator.DryRun = dryRun
+
+	return runCommand(ctx, migrator, args)
+}
+
+// runCommand はコマンドライン引数で指定されたコマンドを実行します
+func runCommand(ctx context.Context, migrator *migration.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("適用するマイグレーションはありません")
+		}
+		return nil
+
+	case "down":
+		n := 1
+		if len(args) >= 2 {
+			var err error
+			n, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("件数が不正です: %s", args[1])
+			}
+		}
+		_, err := migrator.Down(ctx, n)
+		return err
+
+	case "redo":
+		_, err := migrator.Redo(ctx)
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			switch {
+			case s.Missing:
+				fmt.Printf("%04d  %-30s  applied at %s (ファイルなし)\n", s.Version, "?", s.AppliedAt.Format("2006-01-02 15:04:05"))
+			case s.Applied:
+				fmt.Printf("%04d  %-30s  applied at %s\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			default:
+				fmt.Printf("%04d  %-30s  pending\n", s.Version, s.Name)
+			}
+		}
+		return nil
+
+	default:
+		flag.Usage()
+		return fmt.Errorf("不明なコマンドです: %s", args[0])
+	}
+}
@@ -1,15 +1,29 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
+	"net"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/nansystem/go-ddd/internal/infrastructure/mysql"
+	"github.com/nansystem/go-ddd/internal/infrastructure/postgres"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqlite"
+	"github.com/nansystem/go-ddd/internal/logging"
+	"github.com/nansystem/go-ddd/internal/telemetry"
 )
 
 type Config struct {
-	DBConfig mysql.DBConfig
+	Server   ServerConfig
+	DBConfig DBConfig
 	GitHub   GitHubConfig
+	Health   HealthConfig
+	Log      logging.Config
+	Tracing  telemetry.Config
+	// UserIDStrategy はユーザーIDの採番方式です (uuidv4, uuidv7, ulid)
+	UserIDStrategy string
 }
 
 var once sync.Once
@@ -26,22 +40,159 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	serverConfig, err := loadServerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.Server = *serverConfig
 	config.DBConfig = *dbConfig
 	config.GitHub = loadGitHubConfig()
 
+	healthConfig, err := loadHealthConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.Health = *healthConfig
+	config.UserIDStrategy = getEnv("USER_ID_STRATEGY", "uuidv7")
+
+	logConfig, err := loadLogConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.Log = *logConfig
+
+	tracingConfig, err := loadTracingConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.Tracing = *tracingConfig
+
 	return config, nil
 }
 
-func loadDBConfig() (*mysql.DBConfig, error) {
-	dbConfig := mysql.DBConfig{
-		User:     getEnv("DB_USER", "ddduser"),
-		Password: getEnv("DB_PASSWORD", "dddpass"),
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnv("DB_PORT", "13306"),
-		DBName:   getEnv("DB_NAME", "go_ddd"),
+// ServerConfig HTTPサーバー設定
+type ServerConfig struct {
+	Host string
+	Port string
+	// ShutdownTimeout は終了シグナル受信後、処理中のリクエストの完了を待つ最大時間です
+	ShutdownTimeout time.Duration
+	// ProblemDetails がtrueの場合、エラーレスポンスを常にRFC 9457形式 (application/problem+json) で返します
+	ProblemDetails bool
+}
+
+// エラーレスポンスの形式
+const (
+	ErrorFormatJSON    = "json"
+	ErrorFormatProblem = "problem"
+)
+
+// Addr はリッスンするアドレスを返します
+func (c ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+func loadServerConfig() (*ServerConfig, error) {
+	serverConfig := ServerConfig{
+		Host: getEnv("SERVER_HOST", ""),
+		Port: getEnv("SERVER_PORT", "8080"),
+	}
+
+	shutdownTimeout, err := time.ParseDuration(getEnv("SHUTDOWN_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("SHUTDOWN_TIMEOUTの形式が不正です: %w", err)
+	}
+	serverConfig.ShutdownTimeout = shutdownTimeout
+
+	switch format := getEnv("ERROR_FORMAT", ErrorFormatJSON); format {
+	case ErrorFormatJSON:
+	case ErrorFormatProblem:
+		serverConfig.ProblemDetails = true
+	default:
+		return nil, fmt.Errorf("ERROR_FORMATの値が不正です: %q (json, problem のいずれかを指定してください)", format)
 	}
 
-	return &dbConfig, nil
+	return &serverConfig, nil
+}
+
+// データベースドライバの種類
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	// DriverMemory はDBを使わずプロセスのメモリに保存します
+	DriverMemory = "memory"
+)
+
+// DBConfig データベース設定
+// Driverで選択したドライバの設定のみが使われます
+type DBConfig struct {
+	Driver   string
+	MySQL    mysql.DBConfig
+	Postgres postgres.DBConfig
+	SQLite   sqlite.DBConfig
+}
+
+// loadDBConfig はDB_DRIVERで選択したドライバの設定を読み込みます
+// 接続先はドライバによらずDB_USER等の共通の環境変数で指定し、ポートの既定値のみドライバごとに異なります (docker-compose.ymlで公開しているポート)
+func loadDBConfig() (*DBConfig, error) {
+	key, driver := dbDriverEnv()
+	defaultPort := "13306"
+	switch driver {
+	case DriverMySQL, DriverSQLite, DriverMemory:
+	case DriverPostgres:
+		defaultPort = "15432"
+	default:
+		return nil, fmt.Errorf("%sの値が不正です: %q (mysql, postgres, sqlite, memory のいずれかを指定してください)", key, driver)
+	}
+
+	queryTimeout, err := time.ParseDuration(getEnv("DB_QUERY_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("DB_QUERY_TIMEOUTの形式が不正です: %w", err)
+	}
+
+	user := getEnv("DB_USER", "ddduser")
+	password := getEnv("DB_PASSWORD", "dddpass")
+	host := getEnv("DB_HOST", "localhost")
+	port := getEnv("DB_PORT", defaultPort)
+	dbName := getEnv("DB_NAME", "go_ddd")
+
+	return &DBConfig{
+		Driver: driver,
+		MySQL: mysql.DBConfig{
+			User:         user,
+			Password:     password,
+			Host:         host,
+			Port:         port,
+			DBName:       dbName,
+			QueryTimeout: queryTimeout,
+		},
+		Postgres: postgres.DBConfig{
+			User:         user,
+			Password:     password,
+			Host:         host,
+			Port:         port,
+			DBName:       dbName,
+			SSLMode:      getEnv("DB_SSLMODE", "disable"),
+			QueryTimeout: queryTimeout,
+		},
+		SQLite: sqlite.DBConfig{
+			Path:         getEnv("SQLITE_PATH", "go_ddd.db"),
+			QueryTimeout: queryTimeout,
+		},
+	}, nil
+}
+
+// dbDriverEnv はドライバを指定する環境変数の名前と値を返します
+// DB_DRIVERがない場合は、以前の設定との互換性のためSTORAGEを使います
+func dbDriverEnv() (key, driver string) {
+	if driver := os.Getenv("DB_DRIVER"); driver != "" {
+		return "DB_DRIVER", driver
+	}
+	if driver := os.Getenv("STORAGE"); driver != "" {
+		return "STORAGE", driver
+	}
+	return "DB_DRIVER", DriverMySQL
 }
 
 func getEnv(key, defaultValue string) string {
@@ -62,3 +213,61 @@ func loadGitHubConfig() GitHubConfig {
 		Token: getEnv("GITHUB_TOKEN", ""),
 	}
 }
+
+// HealthConfig ヘルスチェック設定
+type HealthConfig struct {
+	// CheckTimeout はチェック1件あたりの期限です
+	CheckTimeout time.Duration
+	// CacheTTL はチェック結果を再利用する期間です
+	CacheTTL time.Duration
+}
+
+func loadHealthConfig() (*HealthConfig, error) {
+	checkTimeout, err := time.ParseDuration(getEnv("HEALTH_CHECK_TIMEOUT", "2s"))
+	if err != nil {
+		return nil, fmt.Errorf("HEALTH_CHECK_TIMEOUTの形式が不正です: %w", err)
+	}
+	cacheTTL, err := time.ParseDuration(getEnv("HEALTH_CACHE_TTL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("HEALTH_CACHE_TTLの形式が不正です: %w", err)
+	}
+	return &HealthConfig{CheckTimeout: checkTimeout, CacheTTL: cacheTTL}, nil
+}
+
+func loadLogConfig() (*logging.Config, error) {
+	format := getEnv("LOG_FORMAT", logging.FormatJSON)
+	if format != logging.FormatJSON && format != logging.FormatText {
+		return nil, fmt.Errorf("LOG_FORMATの値が不正です: %q (json, text のいずれかを指定してください)", format)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(getEnv("LOG_LEVEL", "info"))); err != nil {
+		return nil, fmt.Errorf("LOG_LEVELの値が不正です (debug, info, warn, error のいずれかを指定してください): %w", err)
+	}
+
+	return &logging.Config{Format: format, Level: level}, nil
+}
+
+// loadTracingConfig はトレースの設定を読み込みます
+// コレクターの送信先 (OTEL_EXPORTER_OTLP_ENDPOINT) が設定されていればOTLP、なければファイル (TRACE_FILE) を既定にします
+// 標準出力はアプリケーションのログと混ざるため、TRACE_EXPORTER=stdoutで明示した場合のみ使います
+// トレースを出力しない場合はTRACE_EXPORTER=noneを指定します
+func loadTracingConfig() (*telemetry.Config, error) {
+	defaultExporter := telemetry.ExporterFile
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		defaultExporter = telemetry.ExporterOTLP
+	}
+
+	exporter := getEnv("TRACE_EXPORTER", defaultExporter)
+	switch exporter {
+	case telemetry.ExporterOTLP, telemetry.ExporterStdout, telemetry.ExporterFile, telemetry.ExporterNone:
+	default:
+		return nil, fmt.Errorf("TRACE_EXPORTERの値が不正です: %q (otlp, stdout, file, none のいずれかを指定してください)", exporter)
+	}
+
+	return &telemetry.Config{
+		ServiceName: getEnv("OTEL_SERVICE_NAME", "go-ddd"),
+		Exporter:    exporter,
+		FilePath:    getEnv("TRACE_FILE", "traces.jsonl"),
+	}, nil
+}
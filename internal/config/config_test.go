@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/telemetry"
+)
+
+func TestLoadDBConfig_Driver(t *testing.T) {
+	tests := []struct {
+		name        string
+		dbDriver    string
+		storage     string
+		expected    string
+		expectedErr string
+	}{
+		{name: "未指定はMySQL", expected: DriverMySQL},
+		{name: "DB_DRIVERで指定する", dbDriver: DriverSQLite, expected: DriverSQLite},
+		{name: "STORAGEも引き続き使える", storage: DriverMemory, expected: DriverMemory},
+		{name: "両方ある場合はDB_DRIVERを優先する", dbDriver: DriverPostgres, storage: DriverMemory, expected: DriverPostgres},
+		{name: "不正な値は指定した環境変数の名前を含めてエラー", storage: "oracle", expectedErr: "STORAGEの値が不正です"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_DRIVER", tt.dbDriver)
+			t.Setenv("STORAGE", tt.storage)
+
+			cfg, err := loadDBConfig()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.Driver)
+		})
+	}
+}
+
+func TestLoadDBConfig_DefaultPort(t *testing.T) {
+	tests := []struct {
+		driver   string
+		expected string
+	}{
+		// docker-compose.ymlで公開しているポート
+		{driver: DriverMySQL, expected: "13306"},
+		{driver: DriverPostgres, expected: "15432"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			t.Setenv("DB_DRIVER", tt.driver)
+			t.Setenv("DB_PORT", "")
+
+			cfg, err := loadDBConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.MySQL.Port)
+			assert.Equal(t, tt.expected, cfg.Postgres.Port)
+		})
+	}
+}
+
+func TestLoadTracingConfig_DefaultExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		endpoint string
+		expected string
+	}{
+		{name: "送信先がなければファイル", expected: telemetry.ExporterFile},
+		{name: "送信先があればOTLP", endpoint: "http://localhost:4318", expected: telemetry.ExporterOTLP},
+		{name: "noneを明示すれば出力しない", exporter: telemetry.ExporterNone, expected: telemetry.ExporterNone},
+		{name: "送信先があってもnoneを優先する", exporter: telemetry.ExporterNone, endpoint: "http://localhost:4318", expected: telemetry.ExporterNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRACE_EXPORTER", tt.exporter)
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.endpoint)
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+			cfg, err := loadTracingConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.Exporter)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package domainerror
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // 基本的なエラー種類の定義
@@ -22,6 +23,12 @@ var (
 	// ErrInternal は内部エラーです
 	ErrInternal = errors.New("内部エラーが発生しました")
 
+	// ErrTimeout は処理が期限内に完了しなかった場合のエラーです
+	ErrTimeout = errors.New("処理がタイムアウトしました")
+
+	// ErrCanceled は呼び出し元により処理が中断された場合のエラーです
+	ErrCanceled = errors.New("処理がキャンセルされました")
+
 	// データベース関連エラー
 	ErrDatabase    = errors.New("データベースエラーが発生しました")
 	ErrConnection  = errors.New("データベース接続エラーが発生しました")
@@ -45,6 +52,14 @@ func (e *NotFoundError) Is(target error) bool {
 	return target == ErrNotFound
 }
 
+// MessageKey はメッセージキーを返します
+func (e *NotFoundError) MessageKey() string { return MsgEntityNotFound }
+
+// MessageParams はメッセージに埋め込むパラメータを返します
+func (e *NotFoundError) MessageParams() map[string]any {
+	return map[string]any{"entity": e.EntityName, "id": e.ID}
+}
+
 // NewNotFoundError は新しいNotFoundErrorを作成します
 func NewNotFoundError(entityName, id string) *NotFoundError {
 	return &NotFoundError{
@@ -54,27 +69,76 @@ func NewNotFoundError(entityName, id string) *NotFoundError {
 }
 
 // ValidationError は入力値の検証エラーです
+// 理由はクライアントの言語で表示できるよう、メッセージカタログのキーとパラメータで持ちます
 type ValidationError struct {
-	Field   string
-	Message string
+	Field  string
+	Key    string
+	Params map[string]any
 }
 
-// Error はエラーメッセージを返します
+// Error はログ向けに項目名とメッセージキー、パラメータを返します
+// クライアントに表示するメッセージは表示する層がMessageKeyとMessageParamsから翻訳します
 func (e *ValidationError) Error() string {
-	return fmt.Sprintf("Field %s: %s", e.Field, e.Message)
+	if len(e.Params) == 0 {
+		return fmt.Sprintf("Field %s: %s", e.Field, e.Key)
+	}
+	return fmt.Sprintf("Field %s: %s %v", e.Field, e.Key, e.Params)
 }
 
+// MessageKey は理由のメッセージキーを返します
+func (e *ValidationError) MessageKey() string { return e.Key }
+
+// MessageParams は理由のメッセージに埋め込むパラメータを返します
+func (e *ValidationError) MessageParams() map[string]any { return e.Params }
+
 // Is はエラー比較を行います
 func (e *ValidationError) Is(target error) bool {
 	return target == ErrInvalidInput
 }
 
 // NewValidationError は新しいValidationErrorを作成します
-func NewValidationError(field, message string) *ValidationError {
+// keyは理由を表すメッセージカタログのキーです
+func NewValidationError(field, key string) *ValidationError {
+	return &ValidationError{
+		Field: field,
+		Key:   key,
+	}
+}
+
+// NewValidationErrorWithParams はメッセージに埋め込むパラメータを持つValidationErrorを作成します
+func NewValidationErrorWithParams(field, key string, params map[string]any) *ValidationError {
 	return &ValidationError{
-		Field:   field,
-		Message: message,
+		Field:  field,
+		Key:    key,
+		Params: params,
+	}
+}
+
+// ValidationErrors は複数項目の検証エラーです
+// 入力のすべての違反を1度に返すために使います
+type ValidationErrors []*ValidationError
+
+// Error は各項目のエラーメッセージを1行ずつ返します
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is はエラー比較を行います
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// Unwrap は各項目のエラーを返します
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
 	}
+	return errs
 }
 
 // DuplicateEntryError は重複エントリのエラーを表します
@@ -93,6 +157,14 @@ func (e *DuplicateEntryError) Is(target error) bool {
 	return target == ErrDuplicated
 }
 
+// MessageKey はメッセージキーを返します
+func (e *DuplicateEntryError) MessageKey() string { return MsgDuplicateEntry }
+
+// MessageParams はメッセージに埋め込むパラメータを返します
+func (e *DuplicateEntryError) MessageParams() map[string]any {
+	return map[string]any{"id": e.ID, "name": e.Name}
+}
+
 // NewDuplicateEntryError は新しいDuplicateEntryErrorを作成します
 func NewDuplicateEntryError(id, name string) *DuplicateEntryError {
 	return &DuplicateEntryError{
@@ -103,6 +175,7 @@ func NewDuplicateEntryError(id, name string) *DuplicateEntryError {
 
 // DatabaseError はデータベース操作に関するエラーを表します
 type DatabaseError struct {
+	Kind      error  // エラーの分類 (ErrConnection, ErrTransaction, ErrQuery のいずれか。nilの場合は未分類)
 	Operation string // 実行しようとした操作 (select, insert, update, delete など)
 	Table     string // 対象テーブル
 	Err       error  // 元のエラー
@@ -114,6 +187,52 @@ func (e *DatabaseError) Error() string {
 }
 
 // Is はエラー比較を行います
+// ErrDatabaseと、分類されている場合はその分類に一致します
 func (e *DatabaseError) Is(target error) bool {
-	return target == ErrDatabase || target == ErrConnection || target == ErrTransaction || target == ErrQuery
+	return target == ErrDatabase || (e.Kind != nil && target == e.Kind)
+}
+
+// Unwrap は元のエラーを返します
+func (e *DatabaseError) Unwrap() error {
+	return e.Err
+}
+
+// NewDatabaseError は新しいDatabaseErrorを作成します
+func NewDatabaseError(kind error, operation, table string, err error) *DatabaseError {
+	return &DatabaseError{
+		Kind:      kind,
+		Operation: operation,
+		Table:     table,
+		Err:       err,
+	}
+}
+
+// DuplicateEmailError はメールアドレスの重複エラーを表します
+type DuplicateEmailError struct {
+	Email string
+}
+
+// Error はエラーメッセージを返します
+func (e *DuplicateEmailError) Error() string {
+	return fmt.Sprintf("メールアドレスは既に使用されています: %s", e.Email)
+}
+
+// Is はエラー比較を行います
+func (e *DuplicateEmailError) Is(target error) bool {
+	return target == ErrDuplicated
+}
+
+// MessageKey はメッセージキーを返します
+func (e *DuplicateEmailError) MessageKey() string { return MsgDuplicateEmail }
+
+// MessageParams はメッセージに埋め込むパラメータを返します
+func (e *DuplicateEmailError) MessageParams() map[string]any {
+	return map[string]any{"email": e.Email}
+}
+
+// NewDuplicateEmailError は新しいDuplicateEmailErrorを作成します
+func NewDuplicateEmailError(email string) *DuplicateEmailError {
+	return &DuplicateEmailError{
+		Email: email,
+	}
 }
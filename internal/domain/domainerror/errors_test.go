@@ -0,0 +1,72 @@
+package domainerror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+func TestDatabaseError(t *testing.T) {
+	cause := errors.New("driver error")
+
+	tests := []struct {
+		name        string
+		err         *domainerror.DatabaseError
+		matches     []error
+		doesntMatch []error
+	}{
+		{
+			name:        "接続エラー",
+			err:         domainerror.NewDatabaseError(domainerror.ErrConnection, "select", "users", cause),
+			matches:     []error{domainerror.ErrDatabase, domainerror.ErrConnection, cause},
+			doesntMatch: []error{domainerror.ErrTransaction, domainerror.ErrQuery},
+		},
+		{
+			name:        "トランザクションエラー",
+			err:         domainerror.NewDatabaseError(domainerror.ErrTransaction, "update", "users", cause),
+			matches:     []error{domainerror.ErrDatabase, domainerror.ErrTransaction, cause},
+			doesntMatch: []error{domainerror.ErrConnection, domainerror.ErrQuery},
+		},
+		{
+			name:        "未分類",
+			err:         domainerror.NewDatabaseError(nil, "insert", "users", cause),
+			matches:     []error{domainerror.ErrDatabase, cause},
+			doesntMatch: []error{domainerror.ErrConnection, domainerror.ErrTransaction, domainerror.ErrQuery},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("wrap: %w", tt.err)
+			for _, target := range tt.matches {
+				assert.ErrorIs(t, wrapped, target)
+			}
+			for _, target := range tt.doesntMatch {
+				assert.NotErrorIs(t, wrapped, target)
+			}
+			assert.Same(t, cause, errors.Unwrap(tt.err))
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	errs := domainerror.ValidationErrors{
+		domainerror.NewValidationError("Name", "user.name.required"),
+		domainerror.NewValidationErrorWithParams("limit", "user.query.limit_out_of_range", map[string]any{"min": 1, "max": 100}),
+	}
+	wrapped := fmt.Errorf("wrap: %w", errs)
+
+	assert.ErrorIs(t, wrapped, domainerror.ErrInvalidInput)
+	// 翻訳は表示する層で行うため、メッセージキーとパラメータのみを含める
+	assert.Equal(t, "Field Name: user.name.required\nField limit: user.query.limit_out_of_range map[max:100 min:1]", errs.Error())
+
+	// 各項目のエラーも取り出せる
+	var verr *domainerror.ValidationError
+	if assert.ErrorAs(t, wrapped, &verr) {
+		assert.Equal(t, "Name", verr.Field)
+	}
+}
@@ -0,0 +1,27 @@
+package domainerror
+
+// メッセージカタログ (internal/i18n/locales) のキー
+const (
+	// MsgEntityNotFound のパラメータ: entity, id
+	MsgEntityNotFound = "error.entity_not_found"
+	// MsgDuplicateEntry のパラメータ: id, name
+	MsgDuplicateEntry = "error.duplicate_entry"
+	// MsgDuplicateEmail のパラメータ: email
+	MsgDuplicateEmail = "error.duplicate_email"
+	// MsgValidation は検証エラーの表示形式です (パラメータ: field, reason)
+	MsgValidation = "error.validation"
+
+	// 以下は検証エラーの理由です
+	MsgMustBeInteger  = "validation.integer"
+	MsgMustBeDateTime = "validation.datetime"
+	MsgInvalidType    = "validation.type"
+	MsgUnknownField   = "validation.unknown_field"
+)
+
+// Localizable はクライアントに表示するメッセージをカタログのキーとパラメータで表すエラーです
+// 表示する層がクライアントの言語に翻訳します
+type Localizable interface {
+	error
+	MessageKey() string
+	MessageParams() map[string]any
+}
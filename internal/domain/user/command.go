@@ -0,0 +1,112 @@
+package user
+
+import (
+	"strings"
+
+	"github.com/nansystem/go-ddd/internal/domain/validation"
+)
+
+// MaxNameLength は名前の最大文字数です (usersテーブルのnameカラムに合わせる)
+const MaxNameLength = 100
+
+// CreateCommand はユーザー作成の入力です
+type CreateCommand struct {
+	// ID はサーバーで採番するため、指定された場合は違反とします
+	ID    string
+	Name  string
+	Email string
+}
+
+// Validate はすべての項目を検証し、違反をまとめて返します
+func (c CreateCommand) Validate() error {
+	return validation.Validate(
+		validation.Field("id", c.ID, validation.Custom(MsgIDAssignedByServer, func(v string) bool { return v == "" })),
+		nameField(c.Name),
+		emailField(c.Email),
+	)
+}
+
+// ToUser は検証済みの入力からユーザーを作成します (IDは未採番)
+func (c CreateCommand) ToUser() (*User, error) {
+	email, err := NewEmail(c.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &User{Name: c.Name, Email: email}, nil
+}
+
+// UpdateCommand はユーザーの全項目を置き換える更新の入力です
+type UpdateCommand struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+// Validate はすべての項目を検証し、違反をまとめて返します
+func (c UpdateCommand) Validate() error {
+	return validation.Validate(
+		nameField(c.Name),
+		emailField(c.Email),
+	)
+}
+
+// ToUser は検証済みの入力からユーザーを作成します
+func (c UpdateCommand) ToUser() (*User, error) {
+	email, err := NewEmail(c.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &User{ID: c.ID, Name: c.Name, Email: email}, nil
+}
+
+// PatchCommand はユーザーの部分更新の入力です
+// nilの項目は更新せず、検証もしません
+type PatchCommand struct {
+	Name  *string
+	Email *string
+}
+
+// Validate は指定された項目を検証し、違反をまとめて返します
+func (c PatchCommand) Validate() error {
+	return validation.Validate(
+		validation.OptionalField("name", c.Name, nameRules()...),
+		optionalEmailField(c.Email),
+	)
+}
+
+func nameField(name string) validation.FieldRules {
+	return validation.Field("name", name, nameRules()...)
+}
+
+func nameRules() []validation.Rule {
+	return []validation.Rule{
+		validation.WithMessage(validation.Required(), MsgNameRequired),
+		validation.MaxLength(MaxNameLength),
+	}
+}
+
+// emailField はNewEmailと同じく正規化した値を検証します
+func emailField(email string) validation.FieldRules {
+	return validation.Field("email", normalizeEmail(email), emailRules()...)
+}
+
+func optionalEmailField(email *string) validation.FieldRules {
+	if email == nil {
+		return validation.OptionalField("email", nil)
+	}
+	return emailField(*email)
+}
+
+// emailRules は正規化済みのメールアドレスに適用する規則です
+// 表示名付きの形式 ("Name <a@example.com>") は受け付けません
+func emailRules() []validation.Rule {
+	return []validation.Rule{
+		validation.WithMessage(validation.Required(), MsgEmailRequired),
+		validation.Custom(MsgEmailTooLong, func(v string) bool { return len(v) <= maxEmailLength }),
+		validation.WithMessage(validation.Email(), MsgEmailInvalid),
+		validation.Custom(MsgEmailInvalid, func(v string) bool {
+			local, domain, _ := strings.Cut(v, "@")
+			return len(local) <= maxEmailLocalLength && strings.Contains(domain, ".")
+		}),
+	}
+}
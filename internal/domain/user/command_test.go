@@ -0,0 +1,89 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/validation"
+)
+
+// violations は検証エラーを 項目:キー の一覧に変換します
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs domainerror.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var result []string
+	for _, v := range errs {
+		result = append(result, v.Field+":"+v.Key)
+	}
+	return result
+}
+
+func TestCreateCommand_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      user.CreateCommand
+		expected []string
+	}{
+		{name: "成功", cmd: user.CreateCommand{Name: "田中太郎", Email: "tanaka@example.com"}},
+		{name: "成功: NewEmailと同じく正規化してから検証する", cmd: user.CreateCommand{Name: "田中太郎", Email: " Tanaka@Example.COM "}},
+		{
+			name:     "失敗: すべての違反をまとめて返す",
+			cmd:      user.CreateCommand{ID: "client-id", Name: " ", Email: "not-an-email"},
+			expected: []string{"id:" + user.MsgIDAssignedByServer, "name:" + user.MsgNameRequired, "email:" + user.MsgEmailInvalid},
+		},
+		{
+			name:     "失敗: 名前が長すぎる",
+			cmd:      user.CreateCommand{Name: strings.Repeat("あ", user.MaxNameLength+1), Email: "tanaka@example.com"},
+			expected: []string{"name:" + validation.MsgMaxLength},
+		},
+		{
+			name:     "失敗: メールアドレスが空",
+			cmd:      user.CreateCommand{Name: "田中太郎"},
+			expected: []string{"email:" + user.MsgEmailRequired},
+		},
+		{
+			name:     "失敗: メールアドレスが長すぎる",
+			cmd:      user.CreateCommand{Name: "田中太郎", Email: strings.Repeat("a", 250) + "@example.com"},
+			expected: []string{"email:" + user.MsgEmailTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, violations(t, tt.cmd.Validate()))
+		})
+	}
+}
+
+func TestPatchCommand_Validate(t *testing.T) {
+	empty, valid, invalid := "", "田中太郎", "invalid"
+
+	tests := []struct {
+		name     string
+		cmd      user.PatchCommand
+		expected []string
+	}{
+		{name: "成功: 指定なし", cmd: user.PatchCommand{}},
+		{name: "成功: 名前のみ", cmd: user.PatchCommand{Name: &valid}},
+		{
+			name:     "失敗: 指定した項目のみ検証する",
+			cmd:      user.PatchCommand{Name: &empty, Email: &invalid},
+			expected: []string{"name:" + user.MsgNameRequired, "email:" + user.MsgEmailInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, violations(t, tt.cmd.Validate()))
+		})
+	}
+}
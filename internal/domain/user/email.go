@@ -0,0 +1,58 @@
+package user
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// メールアドレスの長さ制限 (RFC 5321)
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+)
+
+// Email はユーザーのメールアドレスを表す値オブジェクトです
+// 生成時に検証と正規化 (前後空白の除去、小文字化) を行うため、
+// 同じアドレスは常に同じ値として比較できます
+type Email struct {
+	value string
+}
+
+// NewEmail は文字列を検証・正規化してEmailを作成します
+func NewEmail(s string) (Email, error) {
+	normalized := normalizeEmail(s)
+	for _, rule := range emailRules() {
+		if v := rule(normalized); v != nil {
+			return Email{}, domainerror.NewValidationErrorWithParams("email", v.Key, v.Params)
+		}
+	}
+	return Email{value: normalized}, nil
+}
+
+// normalizeEmail は前後の空白を除去して小文字にそろえます
+func normalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// ReconstructEmail は永続化済みの値からEmailを復元します
+// 保存時に検証済みであることを前提とし、再検証は行いません
+func ReconstructEmail(s string) Email {
+	return Email{value: s}
+}
+
+// String はメールアドレスの文字列表現を返します
+func (e Email) String() string {
+	return e.value
+}
+
+// IsZero は値が未設定かどうかを返します
+func (e Email) IsZero() bool {
+	return e.value == ""
+}
+
+// MarshalJSON はメールアドレスをJSON文字列として出力します
+func (e Email) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.value)
+}
@@ -0,0 +1,42 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+func TestNewEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "成功: 通常のアドレス", input: "test@example.com", expected: "test@example.com"},
+		{name: "成功: 前後の空白と大文字を正規化", input: "  Test.User@Example.COM ", expected: "test.user@example.com"},
+		{name: "成功: サブアドレス", input: "test+tag@mail.example.co.jp", expected: "test+tag@mail.example.co.jp"},
+		{name: "失敗: 空文字", input: "   ", wantErr: true},
+		{name: "失敗: @がない", input: "test.example.com", wantErr: true},
+		{name: "失敗: ドメインにドットがない", input: "test@localhost", wantErr: true},
+		{name: "失敗: 表示名付き", input: "Test <test@example.com>", wantErr: true},
+		{name: "失敗: ローカル部が長すぎる", input: strings.Repeat("a", 65) + "@example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := user.NewEmail(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainerror.ErrInvalidInput)
+				assert.True(t, email.IsZero())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, email.String())
+		})
+	}
+}
@@ -1,10 +1,11 @@
 package user
 
 type User struct {
-	ID   string
-	Name string
+	ID    string
+	Name  string
+	Email Email
 }
 
-func NewUser(id string, name string) *User {
-	return &User{ID: id, Name: name}
+func NewUser(id string, name string, email Email) *User {
+	return &User{ID: id, Name: name, Email: email}
 }
@@ -0,0 +1,7 @@
+package user
+
+// IDGenerator はユーザーIDの採番方式を抽象化します
+// IDはクライアントから受け取らず、ドメインが採番します
+type IDGenerator interface {
+	Generate() (string, error)
+}
@@ -0,0 +1,16 @@
+package user
+
+// メッセージカタログ (internal/i18n/locales) のキー
+const (
+	MsgIDAssignedByServer = "user.id.assigned_by_server"
+	MsgNameRequired       = "user.name.required"
+	MsgEmailRequired      = "user.email.required"
+	MsgEmailTooLong       = "user.email.too_long"
+	MsgEmailInvalid       = "user.email.invalid"
+	// MsgLimitOutOfRange のパラメータ: min, max
+	MsgLimitOutOfRange     = "user.query.limit_out_of_range"
+	MsgSortInvalid         = "user.query.sort_invalid"
+	MsgCreatedRangeInvalid = "user.query.created_range_invalid"
+	MsgCursorSortMismatch  = "user.query.cursor_sort_mismatch"
+	MsgCursorInvalid       = "user.query.cursor_invalid"
+)
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// 一覧取得の件数制限
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// SortField は一覧の並び替えに使う項目です
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortByCreatedAt SortField = "created_at"
+)
+
+// Sort は並び順を表します
+// 同じ値の行の順序を安定させるため、リポジトリは常にIDを第2キーとして使います
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// Query はユーザー一覧の検索条件を表す仕様です
+// リポジトリはこの仕様を各永続化方式のクエリに変換します
+type Query struct {
+	Limit int
+	// After は前ページの末尾を指すカーソルです (nilの場合は先頭から)
+	After *Cursor
+	Sort  Sort
+	// NamePrefix は名前の前方一致条件です (空の場合は絞り込まない)
+	NamePrefix string
+	// CreatedFrom, CreatedTo は作成日時の範囲 [From, To) です (ゼロ値の場合は絞り込まない)
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// NewQuery はデフォルト値を設定したQueryを作成します
+func NewQuery() Query {
+	return Query{
+		Limit: DefaultLimit,
+		Sort:  Sort{Field: SortByCreatedAt},
+	}
+}
+
+// Validate は検索条件の整合性を検証します
+func (q Query) Validate() error {
+	if q.Limit < 1 || q.Limit > MaxLimit {
+		return domainerror.NewValidationErrorWithParams("limit", MsgLimitOutOfRange, map[string]any{"min": 1, "max": MaxLimit})
+	}
+	if q.Sort.Field != SortByName && q.Sort.Field != SortByCreatedAt {
+		return domainerror.NewValidationError("sort", MsgSortInvalid)
+	}
+	if !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero() && !q.CreatedFrom.Before(q.CreatedTo) {
+		return domainerror.NewValidationError("created_from", MsgCreatedRangeInvalid)
+	}
+	// 異なる並び順で発行されたカーソルは位置の意味が変わるため受け付けない
+	if q.After != nil && q.After.Sort != q.Sort {
+		return domainerror.NewValidationError("cursor", MsgCursorSortMismatch)
+	}
+	return nil
+}
+
+// Cursor はキーセットページネーションの位置を表します
+// 並び替え項目の値 (Key) とID の組で、前ページの最後の行を特定します
+type Cursor struct {
+	Sort Sort
+	Key  string
+	ID   string
+}
+
+// cursorPayload はカーソルのシリアライズ形式です
+type cursorPayload struct {
+	Field SortField `json:"f"`
+	Desc  bool      `json:"d,omitempty"`
+	Key   string    `json:"k"`
+	ID    string    `json:"i"`
+}
+
+// NewCursor は行の並び替え項目の値とIDからカーソルを作成します
+func NewCursor(sort Sort, u *User, createdAt time.Time) *Cursor {
+	key := u.Name
+	if sort.Field == SortByCreatedAt {
+		key = createdAt.UTC().Format(time.RFC3339Nano)
+	}
+	return &Cursor{Sort: sort, Key: key, ID: u.ID}
+}
+
+// CreatedAt はcreated_at順のカーソルが指す作成日時を返します
+func (c *Cursor) CreatedAt() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Key)
+	if err != nil {
+		return time.Time{}, domainerror.NewValidationError("cursor", MsgCursorInvalid)
+	}
+	return t, nil
+}
+
+// Encode はカーソルをクライアントに返す不透明な文字列に変換します
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(cursorPayload{Field: c.Sort.Field, Desc: c.Sort.Desc, Key: c.Key, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor はEncodeで生成された文字列からカーソルを復元します
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domainerror.NewValidationError("cursor", MsgCursorInvalid)
+	}
+	var p cursorPayload
+	if err := json.Unmarshal(b, &p); err != nil || p.ID == "" {
+		return nil, domainerror.NewValidationError("cursor", MsgCursorInvalid)
+	}
+	c := &Cursor{Sort: Sort{Field: p.Field, Desc: p.Desc}, Key: p.Key, ID: p.ID}
+	if c.Sort.Field == SortByCreatedAt {
+		if _, err := c.CreatedAt(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Page は一覧取得の結果です
+type Page struct {
+	Users []*User
+	// NextCursor は次ページの取得に使うカーソルです (最終ページの場合はnil)
+	NextCursor *Cursor
+}
@@ -0,0 +1,77 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	u := &user.User{ID: "10000000-0000-0000-0000-000000000001", Name: "田中太郎"}
+
+	for _, sort := range []user.Sort{
+		{Field: user.SortByName},
+		{Field: user.SortByCreatedAt, Desc: true},
+	} {
+		t.Run(string(sort.Field), func(t *testing.T) {
+			cursor := user.NewCursor(sort, u, createdAt)
+			decoded, err := user.DecodeCursor(cursor.Encode())
+			require.NoError(t, err)
+			assert.Equal(t, cursor, decoded)
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"!!!", "e30", "eyJmIjoiY3JlYXRlZF9hdCIsImsiOiJ4IiwiaSI6IjEifQ"} {
+		_, err := user.DecodeCursor(s)
+		assert.ErrorIs(t, err, domainerror.ErrInvalidInput, s)
+	}
+}
+
+func TestQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(q *user.Query)
+		wantErr bool
+	}{
+		{name: "成功: デフォルト値", modify: func(_ *user.Query) {}},
+		{name: "失敗: limitが0", modify: func(q *user.Query) { q.Limit = 0 }, wantErr: true},
+		{name: "失敗: limitが上限超過", modify: func(q *user.Query) { q.Limit = user.MaxLimit + 1 }, wantErr: true},
+		{name: "失敗: 未対応の並び替え項目", modify: func(q *user.Query) { q.Sort.Field = "email" }, wantErr: true},
+		{
+			name: "失敗: 日時の範囲が逆転",
+			modify: func(q *user.Query) {
+				q.CreatedFrom = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+				q.CreatedTo = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			},
+			wantErr: true,
+		},
+		{
+			name: "失敗: カーソルと並び順が一致しない",
+			modify: func(q *user.Query) {
+				q.After = &user.Cursor{Sort: user.Sort{Field: user.SortByName}, Key: "a", ID: "1"}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := user.NewQuery()
+			tt.modify(&q)
+			err := q.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainerror.ErrInvalidInput)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,14 @@
 package user
 
+import "context"
+
 type Repository interface {
-	GetUsers() ([]*User, error)
-	GetUserByID(id string) (*User, error)
-	CreateUser(user *User) error
+	GetUsers(ctx context.Context, query Query) (*Page, error)
+	GetUserByID(ctx context.Context, id string) (*User, error)
+	// GetUserByIDForUpdate はトランザクション内で行をロックしてユーザーを取得します
+	// 読み込んだ値をもとに更新する場合に使い、同時に実行された更新が失われないようにします
+	GetUserByIDForUpdate(ctx context.Context, id string) (*User, error)
+	CreateUser(ctx context.Context, user *User) error
+	UpdateUser(ctx context.Context, user *User) error
+	DeleteUser(ctx context.Context, id string) error
 }
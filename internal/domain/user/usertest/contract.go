@@ -0,0 +1,210 @@
+// Package usertest はuser.Repositoryの実装が共通して満たすべき振る舞いを検証するテストスイートを提供します
+package usertest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+// RepositoryFactory はテストごとに空のリポジトリを作成します
+type RepositoryFactory func(t *testing.T) user.Repository
+
+// RunRepositoryContract はuser.Repositoryの契約テストを実行します
+// 各サブテストはnewRepositoryで作成した空のリポジトリを使います
+func RunRepositoryContract(t *testing.T, newRepository RepositoryFactory) {
+	t.Helper()
+
+	t.Run("作成したユーザーをIDで取得できる", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		u := newUser("u1", "田中太郎", "tanaka@example.com")
+
+		require.NoError(t, repo.CreateUser(ctx, u))
+
+		got, err := repo.GetUserByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, u, got)
+	})
+
+	t.Run("存在しないIDはNotFoundError", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.GetUserByID(context.Background(), "missing")
+		var notFoundErr *domainerror.NotFoundError
+		require.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "missing", notFoundErr.ID)
+
+		_, err = repo.GetUserByIDForUpdate(context.Background(), "missing")
+		assert.ErrorIs(t, err, domainerror.ErrNotFound)
+	})
+
+	t.Run("更新用にロックして取得できる", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		u := newUser("u1", "田中太郎", "tanaka@example.com")
+		require.NoError(t, repo.CreateUser(ctx, u))
+
+		got, err := repo.GetUserByIDForUpdate(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, u, got)
+	})
+
+	t.Run("IDの重複はDuplicateEntryError", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+
+		err := repo.CreateUser(ctx, newUser("u1", "山田花子", "yamada@example.com"))
+		var duplicateErr *domainerror.DuplicateEntryError
+		assert.ErrorAs(t, err, &duplicateErr)
+	})
+
+	t.Run("メールアドレスの重複はDuplicateEmailError", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+
+		err := repo.CreateUser(ctx, newUser("u2", "田中次郎", "tanaka@example.com"))
+		var duplicateErr *domainerror.DuplicateEmailError
+		assert.ErrorAs(t, err, &duplicateErr)
+
+		// 更新による重複も検出する
+		require.NoError(t, repo.CreateUser(ctx, newUser("u3", "山田花子", "yamada@example.com")))
+		err = repo.UpdateUser(ctx, newUser("u3", "山田花子", "tanaka@example.com"))
+		assert.ErrorAs(t, err, &duplicateErr)
+	})
+
+	t.Run("更新した内容を取得できる", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+
+		updated := newUser("u1", "田中一郎", "ichiro@example.com")
+		require.NoError(t, repo.UpdateUser(ctx, updated))
+		// 値が変わらない更新も成功する
+		require.NoError(t, repo.UpdateUser(ctx, updated))
+
+		got, err := repo.GetUserByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, updated, got)
+	})
+
+	t.Run("存在しないユーザーの更新と削除はNotFoundError", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		assert.ErrorIs(t, repo.UpdateUser(ctx, newUser("missing", "名前", "missing@example.com")), domainerror.ErrNotFound)
+		assert.ErrorIs(t, repo.DeleteUser(ctx, "missing"), domainerror.ErrNotFound)
+	})
+
+	t.Run("削除したユーザーは取得できない", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+
+		require.NoError(t, repo.DeleteUser(ctx, "u1"))
+
+		_, err := repo.GetUserByID(ctx, "u1")
+		assert.ErrorIs(t, err, domainerror.ErrNotFound)
+	})
+
+	t.Run("名前順にページ送りできる", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		// 同名のユーザーはIDで順序が決まる
+		for i, name := range []string{"c", "a", "b", "a", "d"} {
+			require.NoError(t, repo.CreateUser(ctx, newUser(fmt.Sprintf("u%d", i), name, fmt.Sprintf("u%d@example.com", i))))
+		}
+
+		query := user.NewQuery()
+		query.Limit = 2
+		query.Sort = user.Sort{Field: user.SortByName}
+		assert.Equal(t, []string{"u1", "u3", "u2", "u0", "u4"}, collectIDs(t, repo, query))
+
+		query.Sort.Desc = true
+		assert.Equal(t, []string{"u4", "u0", "u2", "u3", "u1"}, collectIDs(t, repo, query))
+	})
+
+	t.Run("作成日時順にすべてのユーザーを重複なく取得できる", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		for i := range 5 {
+			require.NoError(t, repo.CreateUser(ctx, newUser(fmt.Sprintf("u%d", i), "名前", fmt.Sprintf("u%d@example.com", i))))
+		}
+
+		query := user.NewQuery()
+		query.Limit = 2
+		// 同じ秒に作成された行はIDで順序が決まる
+		assert.Equal(t, []string{"u0", "u1", "u2", "u3", "u4"}, collectIDs(t, repo, query))
+	})
+
+	t.Run("名前の前方一致で絞り込める", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+		require.NoError(t, repo.CreateUser(ctx, newUser("u2", "山田花子", "yamada@example.com")))
+		require.NoError(t, repo.CreateUser(ctx, newUser("u3", "100%_user", "percent@example.com")))
+		require.NoError(t, repo.CreateUser(ctx, newUser("u4", "100abc", "abc@example.com")))
+
+		query := user.NewQuery()
+		query.NamePrefix = "田中"
+		assert.Equal(t, []string{"u1"}, collectIDs(t, repo, query))
+
+		// LIKEの特殊文字は文字どおりに扱う
+		query.NamePrefix = "100%_"
+		assert.Equal(t, []string{"u3"}, collectIDs(t, repo, query))
+	})
+
+	t.Run("作成日時の範囲で絞り込める", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("u1", "田中太郎", "tanaka@example.com")))
+
+		now := time.Now()
+		query := user.NewQuery()
+		query.CreatedFrom = now.Add(-time.Hour)
+		query.CreatedTo = now.Add(time.Hour)
+		assert.Equal(t, []string{"u1"}, collectIDs(t, repo, query))
+
+		query.CreatedFrom = now.Add(-2 * time.Hour)
+		query.CreatedTo = now.Add(-time.Hour)
+		assert.Empty(t, collectIDs(t, repo, query))
+	})
+}
+
+// collectIDs はカーソルをたどって全ページのユーザーIDを取得します
+func collectIDs(t *testing.T, repo user.Repository, query user.Query) []string {
+	t.Helper()
+
+	ids := []string{}
+	for range 100 {
+		page, err := repo.GetUsers(context.Background(), query)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Users), query.Limit)
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == nil {
+			return ids
+		}
+		query.After = page.NextCursor
+	}
+	t.Fatal("ページ送りが終了しません")
+	return nil
+}
+
+func newUser(id, name, email string) *user.User {
+	e, err := user.NewEmail(email)
+	if err != nil {
+		panic(err)
+	}
+	return user.NewUser(id, name, e)
+}
@@ -0,0 +1,150 @@
+// Package validation は入力値を宣言的に検証するための規則を提供します
+// 各項目に規則を並べてValidateに渡すと、すべての項目の違反をまとめたdomainerror.ValidationErrorsを返します
+package validation
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// メッセージカタログ (internal/i18n/locales) のキー
+const (
+	MsgRequired = "validation.required"
+	// MsgMinLength のパラメータ: min
+	MsgMinLength = "validation.min_length"
+	// MsgMaxLength のパラメータ: max
+	MsgMaxLength = "validation.max_length"
+	// MsgPattern のパラメータ: pattern
+	MsgPattern = "validation.pattern"
+	MsgEmail   = "validation.email"
+)
+
+// Violation は規則に違反した理由です
+type Violation struct {
+	Key    string
+	Params map[string]any
+}
+
+// Rule は1つの値に対する検証規則です
+// 違反した場合はその理由を、満たす場合はnilを返します
+// 独自の規則はこの型の関数として定義できます
+type Rule func(value string) *Violation
+
+// FieldRules は項目とその規則です
+type FieldRules struct {
+	name  string
+	value string
+	skip  bool
+	rules []Rule
+}
+
+// Field は項目の値に適用する規則を宣言します
+// 規則は順に評価し、最初の違反のみを報告します
+func Field(name, value string, rules ...Rule) FieldRules {
+	return FieldRules{name: name, value: value, rules: rules}
+}
+
+// OptionalField は値が指定された場合 (nilでない場合) のみ規則を適用します
+func OptionalField(name string, value *string, rules ...Rule) FieldRules {
+	if value == nil {
+		return FieldRules{name: name, skip: true}
+	}
+	return Field(name, *value, rules...)
+}
+
+// Validate はすべての項目を検証し、違反があればdomainerror.ValidationErrorsを返します
+func Validate(fields ...FieldRules) error {
+	var errs domainerror.ValidationErrors
+	for _, f := range fields {
+		if f.skip {
+			continue
+		}
+		for _, rule := range f.rules {
+			if v := rule(f.value); v != nil {
+				errs = append(errs, domainerror.NewValidationErrorWithParams(f.name, v.Key, v.Params))
+				break
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// WithMessage は規則に違反した場合のメッセージキーを差し替えます
+// パラメータはそのまま引き継ぎます
+func WithMessage(rule Rule, key string) Rule {
+	return func(value string) *Violation {
+		v := rule(value)
+		if v == nil {
+			return nil
+		}
+		return &Violation{Key: key, Params: v.Params}
+	}
+}
+
+// Required は空白以外の文字を含むことを検証します
+func Required() Rule {
+	return func(value string) *Violation {
+		if strings.TrimSpace(value) == "" {
+			return &Violation{Key: MsgRequired}
+		}
+		return nil
+	}
+}
+
+// MinLength は文字数 (バイト数ではない) がmin以上であることを検証します
+func MinLength(min int) Rule {
+	return func(value string) *Violation {
+		if utf8.RuneCountInString(value) < min {
+			return &Violation{Key: MsgMinLength, Params: map[string]any{"min": min}}
+		}
+		return nil
+	}
+}
+
+// MaxLength は文字数 (バイト数ではない) がmax以下であることを検証します
+func MaxLength(max int) Rule {
+	return func(value string) *Violation {
+		if utf8.RuneCountInString(value) > max {
+			return &Violation{Key: MsgMaxLength, Params: map[string]any{"max": max}}
+		}
+		return nil
+	}
+}
+
+// Pattern は値全体が正規表現に一致することを検証します
+func Pattern(re *regexp.Regexp) Rule {
+	return func(value string) *Violation {
+		if loc := re.FindStringIndex(value); loc == nil || loc[0] != 0 || loc[1] != len(value) {
+			return &Violation{Key: MsgPattern, Params: map[string]any{"pattern": re.String()}}
+		}
+		return nil
+	}
+}
+
+// Email はメールアドレスの形式 (表示名を含まないアドレスのみ) であることを検証します
+func Email() Rule {
+	return func(value string) *Violation {
+		addr, err := mail.ParseAddress(value)
+		if err != nil || addr.Address != value {
+			return &Violation{Key: MsgEmail}
+		}
+		return nil
+	}
+}
+
+// Custom はokがfalseを返す値をkeyのメッセージで違反とする規則を作成します
+func Custom(key string, ok func(value string) bool) Rule {
+	return func(value string) *Violation {
+		if !ok(value) {
+			return &Violation{Key: key}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+package validation_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/validation"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     validation.Rule
+		value    string
+		expected *validation.Violation
+	}{
+		{name: "Required: 値あり", rule: validation.Required(), value: "a"},
+		{name: "Required: 空白のみ", rule: validation.Required(), value: "  ", expected: &validation.Violation{Key: validation.MsgRequired}},
+		{name: "MinLength: 文字数で数える", rule: validation.MinLength(2), value: "日本"},
+		{name: "MinLength: 不足", rule: validation.MinLength(2), value: "日", expected: &validation.Violation{Key: validation.MsgMinLength, Params: map[string]any{"min": 2}}},
+		{name: "MaxLength: 文字数で数える", rule: validation.MaxLength(2), value: "日本"},
+		{name: "MaxLength: 超過", rule: validation.MaxLength(2), value: "日本語", expected: &validation.Violation{Key: validation.MsgMaxLength, Params: map[string]any{"max": 2}}},
+		{name: "Pattern: 全体が一致", rule: validation.Pattern(regexp.MustCompile(`[a-z]+`)), value: "abc"},
+		{name: "Pattern: 部分一致は違反", rule: validation.Pattern(regexp.MustCompile(`[a-z]+`)), value: "abc1", expected: &validation.Violation{Key: validation.MsgPattern, Params: map[string]any{"pattern": "[a-z]+"}}},
+		{name: "Email: アドレス", rule: validation.Email(), value: "a@example.com"},
+		{name: "Email: 表示名付きは違反", rule: validation.Email(), value: "A <a@example.com>", expected: &validation.Violation{Key: validation.MsgEmail}},
+		{name: "Custom: 満たす", rule: validation.Custom("custom", func(v string) bool { return v == "ok" }), value: "ok"},
+		{name: "Custom: 違反", rule: validation.Custom("custom", func(v string) bool { return v == "ok" }), value: "ng", expected: &validation.Violation{Key: "custom"}},
+		{name: "WithMessage: キーを差し替える", rule: validation.WithMessage(validation.MaxLength(1), "too_long"), value: "ab", expected: &validation.Violation{Key: "too_long", Params: map[string]any{"max": 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule(tt.value))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	name := ""
+
+	err := validation.Validate(
+		validation.Field("Name", "", validation.Required(), validation.MinLength(3)),
+		validation.Field("Code", "abc", validation.MaxLength(5)),
+		validation.Field("Comment", strings.Repeat("a", 11), validation.MaxLength(10)),
+		validation.OptionalField("Nickname", nil, validation.Required()),
+		validation.OptionalField("Alias", &name, validation.Required()),
+	)
+
+	require.ErrorIs(t, err, domainerror.ErrInvalidInput)
+	var errs domainerror.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	// 項目ごとに最初の違反のみを、宣言した順に報告する
+	assert.Equal(t, domainerror.ValidationErrors{
+		domainerror.NewValidationErrorWithParams("Name", validation.MsgRequired, nil),
+		domainerror.NewValidationErrorWithParams("Comment", validation.MsgMaxLength, map[string]any{"max": 10}),
+		domainerror.NewValidationErrorWithParams("Alias", validation.MsgRequired, nil),
+	}, errs)
+}
+
+func TestValidate_NoViolation(t *testing.T) {
+	assert.NoError(t, validation.Validate(
+		validation.Field("Name", "田中", validation.Required()),
+		validation.OptionalField("Nickname", nil, validation.Required()),
+	))
+}
@@ -0,0 +1,185 @@
+// Package health は依存先の死活監視を行うヘルスチェックの仕組みを提供します
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status はヘルスチェックの結果状態です
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker は1つの依存先の状態を確認します
+// 返したdetailsは詳細レポートにそのまま含まれます
+type Checker interface {
+	Check(ctx context.Context) (details map[string]any, err error)
+}
+
+// CheckerFunc は関数をCheckerとして扱うためのアダプタです
+type CheckerFunc func(ctx context.Context) (map[string]any, error)
+
+// Check はf(ctx)を呼び出します
+func (f CheckerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return f(ctx)
+}
+
+// Result は1つのチェックの結果です
+type Result struct {
+	Status    Status         `json:"status"`
+	Error     string         `json:"error,omitempty"`
+	Duration  string         `json:"duration"`
+	CheckedAt time.Time      `json:"checked_at"`
+	Details   map[string]any `json:"details,omitempty"`
+	// Optional は全体の状態に影響しない参考情報のチェックかどうかです
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Report はすべてのチェックの結果をまとめたものです
+// 参考情報のチェックを除き、いずれかのチェックがdownの場合は全体もdownになります
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+type entry struct {
+	name    string
+	checker Checker
+	timeout time.Duration
+	// optional はdownでも全体の状態をdownにしないチェックです
+	optional bool
+
+	mu     sync.Mutex
+	cached *Result
+}
+
+// Registry はヘルスチェックを登録し、まとめて実行します
+// 依存先への負荷を抑えるため、結果はcacheTTLの間キャッシュされます
+type Registry struct {
+	mu       sync.RWMutex
+	entries  []*entry
+	cacheTTL time.Duration
+	now      func() time.Time
+}
+
+// NewRegistry は新しいRegistryを作成します
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{cacheTTL: cacheTTL, now: time.Now}
+}
+
+// Register はチェックを登録します
+// timeoutはチェック1回あたりの期限で、超えた場合はdownとして扱います
+func (r *Registry) Register(name string, checker Checker, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, &entry{name: name, checker: checker, timeout: timeout})
+}
+
+// RegisterOptional は全体の状態に影響しない参考情報のチェックを登録します
+// 外部APIのように、障害時もアプリケーションがリクエストを受け付けられる依存先に使います
+func (r *Registry) RegisterOptional(name string, checker Checker, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, &entry{name: name, checker: checker, timeout: timeout, optional: true})
+}
+
+// Names は登録されたチェックの名前を返します
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		names = append(names, e.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check は登録されたすべてのチェックを並行に実行し、結果をまとめて返します
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := make([]*entry, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, e)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(entries))}
+	for i, e := range entries {
+		report.Checks[e.name] = results[i]
+		if results[i].Status != StatusUp && !e.optional {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run はキャッシュが有効ならそれを返し、期限切れならチェックを実行します
+// 同じチェックへの同時リクエストは1回の実行にまとめられます
+// 呼び出し元のctxが終了した場合の結果は依存先の状態を表さないため、キャッシュしません
+func (r *Registry) run(ctx context.Context, e *entry) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cached != nil && r.now().Sub(e.cached.CheckedAt) < r.cacheTTL {
+		return *e.cached
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	// ctxを無視するCheckerでも期限を守れるよう、別goroutineで実行して待つ
+	type outcome struct {
+		details map[string]any
+		err     error
+	}
+	done := make(chan outcome, 1)
+
+	start := r.now()
+	go func() {
+		details, err := e.checker.Check(checkCtx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var details map[string]any
+	var err error
+	select {
+	case o := <-done:
+		details, err = o.details, o.err
+	case <-checkCtx.Done():
+		err = fmt.Errorf("%s以内に応答がありませんでした: %w", e.timeout, checkCtx.Err())
+	}
+
+	result := Result{
+		Status:    StatusUp,
+		Duration:  r.now().Sub(start).String(),
+		CheckedAt: start,
+		Details:   details,
+		Optional:  e.optional,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	if ctx.Err() == nil {
+		e.cached = &result
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	r := NewRegistry(0)
+	r.Register("ok", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return map[string]any{"open_connections": 1}, nil
+	}), time.Second)
+	r.Register("failing", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	}), time.Second)
+	r.Register("slow", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		// ctxを無視するCheckerでもタイムアウトで打ち切られること
+		time.Sleep(time.Second)
+		return nil, nil
+	}), 10*time.Millisecond)
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["ok"].Status)
+	assert.Equal(t, map[string]any{"open_connections": 1}, report.Checks["ok"].Details)
+	assert.Equal(t, StatusDown, report.Checks["failing"].Status)
+	assert.Equal(t, "connection refused", report.Checks["failing"].Error)
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+	assert.Contains(t, report.Checks["slow"].Error, "context deadline exceeded")
+	assert.Equal(t, []string{"failing", "ok", "slow"}, r.Names())
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	now := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	r := NewRegistry(5 * time.Second)
+	r.now = func() time.Time { return now }
+
+	var calls atomic.Int32
+	r.Register("db", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		calls.Add(1)
+		return nil, nil
+	}), time.Second)
+
+	r.Check(context.Background())
+	now = now.Add(4 * time.Second)
+	r.Check(context.Background())
+	assert.Equal(t, int32(1), calls.Load(), "TTL内はキャッシュを返すこと")
+
+	now = now.Add(2 * time.Second)
+	r.Check(context.Background())
+	assert.Equal(t, int32(2), calls.Load(), "TTL経過後は再実行すること")
+}
+
+func TestRegistry_CanceledContextIsNotCached(t *testing.T) {
+	r := NewRegistry(time.Minute)
+	r.Register("db", CheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		return nil, ctx.Err()
+	}), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := r.Check(ctx)
+	assert.Equal(t, StatusDown, report.Status)
+
+	// 呼び出し元が切断しただけなので、次のリクエストでは再実行すること
+	report = r.Check(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+}
+
+func TestRegistry_Optional(t *testing.T) {
+	r := NewRegistry(0)
+	r.Register("db", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return nil, nil
+	}), time.Second)
+	r.RegisterOptional("github", CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return nil, errors.New("401 Unauthorized")
+	}), time.Second)
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusUp, report.Status, "参考情報のチェックは全体の状態に影響しないこと")
+	assert.Equal(t, StatusDown, report.Checks["github"].Status)
+	assert.True(t, report.Checks["github"].Optional)
+	assert.False(t, report.Checks["db"].Optional)
+}
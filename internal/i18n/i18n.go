@@ -0,0 +1,149 @@
+// Package i18n はクライアントに返すメッセージの翻訳を提供します
+// メッセージはキーと埋め込むパラメータで表し、言語ごとのカタログから表示用の文字列に変換します
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage は要求された言語に対応していない場合に使う言語です
+const DefaultLanguage = "ja"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Catalog は言語ごとのメッセージカタログです
+type Catalog struct {
+	// messages は言語ごとのキーとメッセージのテンプレートです
+	messages  map[string]map[string]string
+	languages []string
+	matcher   language.Matcher
+}
+
+// Load はfsysの直下にある <言語タグ>.json をカタログとして読み込みます
+// DefaultLanguageのファイルは必須です
+// テンプレート中の {name} はTranslateに渡したパラメータに置き換えられます
+func Load(fsys fs.FS) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{messages: map[string]map[string]string{}}
+	for _, file := range files {
+		lang := strings.TrimSuffix(path.Base(file), ".json")
+		if _, err := language.Parse(lang); err != nil {
+			return nil, fmt.Errorf("メッセージカタログの言語タグが不正です: %s: %w", file, err)
+		}
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return nil, fmt.Errorf("メッセージカタログを読み込めません: %s: %w", file, err)
+		}
+		c.messages[lang] = messages
+		c.languages = append(c.languages, lang)
+	}
+	if _, ok := c.messages[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("既定の言語 (%s) のメッセージカタログがありません", DefaultLanguage)
+	}
+
+	// 言語を特定できない場合に選ばれるよう、既定の言語を先頭にする
+	slices.SortFunc(c.languages, func(a, b string) int {
+		switch {
+		case a == DefaultLanguage:
+			return -1
+		case b == DefaultLanguage:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+	tags := make([]language.Tag, len(c.languages))
+	for i, lang := range c.languages {
+		tags[i] = language.MustParse(lang)
+	}
+	c.matcher = language.NewMatcher(tags)
+	return c, nil
+}
+
+// Default は埋め込まれたカタログを返します
+var Default = sync.OnceValue(func() *Catalog {
+	c, err := Load(mustSub(locales, "locales"))
+	if err != nil {
+		panic(err)
+	}
+	return c
+})
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Languages は対応している言語を既定の言語から順に返します
+func (c *Catalog) Languages() []string {
+	return slices.Clone(c.languages)
+}
+
+// Negotiate はAccept-Languageヘッダーの値から応答に使う言語を選びます
+// 対応する言語がない場合や値が不正な場合はDefaultLanguageを返します
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLanguage
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+	_, index, confidence := c.matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return c.languages[index]
+}
+
+// Translate はキーに対応するメッセージをlangで返します
+// langにキーがなければ既定の言語を使い、どちらにもなければキーをそのまま返します
+func (c *Catalog) Translate(lang, key string, params map[string]any) string {
+	template, ok := c.messages[lang][key]
+	if !ok {
+		template, ok = c.messages[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// Keys はlangのカタログに定義されたキーを返します
+func (c *Catalog) Keys(lang string) []string {
+	keys := make([]string, 0, len(c.messages[lang]))
+	for key := range c.messages[lang] {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/validation"
+	"github.com/nansystem/go-ddd/internal/i18n"
+)
+
+func TestDefault_CatalogsHaveSameKeys(t *testing.T) {
+	c := i18n.Default()
+	assert.Equal(t, []string{"ja", "en"}, c.Languages())
+
+	expected := c.Keys(i18n.DefaultLanguage)
+	for _, lang := range c.Languages() {
+		assert.Equal(t, expected, c.Keys(lang), lang)
+	}
+
+	// ドメインが使うキーはすべてカタログに定義されている
+	for _, key := range []string{
+		domainerror.MsgEntityNotFound, domainerror.MsgDuplicateEntry, domainerror.MsgDuplicateEmail,
+		domainerror.MsgValidation, domainerror.MsgMustBeInteger, domainerror.MsgMustBeDateTime,
+		domainerror.MsgInvalidType, domainerror.MsgUnknownField,
+		user.MsgIDAssignedByServer, user.MsgNameRequired, user.MsgEmailRequired, user.MsgEmailTooLong,
+		user.MsgEmailInvalid, user.MsgLimitOutOfRange, user.MsgSortInvalid, user.MsgCreatedRangeInvalid,
+		user.MsgCursorSortMismatch, user.MsgCursorInvalid,
+		validation.MsgRequired, validation.MsgMinLength, validation.MsgMaxLength, validation.MsgPattern, validation.MsgEmail,
+	} {
+		assert.Contains(t, expected, key)
+	}
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "指定がなければ既定の言語", acceptLanguage: "", expected: "ja"},
+		{name: "英語", acceptLanguage: "en", expected: "en"},
+		{name: "地域付きの英語", acceptLanguage: "en-US,en;q=0.9", expected: "en"},
+		{name: "品質値の高い言語を選ぶ", acceptLanguage: "en;q=0.5, ja;q=0.8", expected: "ja"},
+		{name: "未対応の言語は既定の言語", acceptLanguage: "fr", expected: "ja"},
+		{name: "未対応の言語の次の候補を選ぶ", acceptLanguage: "fr, en;q=0.5", expected: "en"},
+		{name: "不正な値は既定の言語", acceptLanguage: "en;q=abc;;", expected: "ja"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, i18n.Default().Negotiate(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ja.json": {Data: []byte(`{"greeting": "こんにちは {name} さん", "only_ja": "日本語のみ"}`)},
+		"en.json": {Data: []byte(`{"greeting": "Hello, {name}"}`)},
+	}
+	c, err := i18n.Load(fsys)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		lang     string
+		key      string
+		params   map[string]any
+		expected string
+	}{
+		{name: "パラメータを埋め込む", lang: "en", key: "greeting", params: map[string]any{"name": "Alice"}, expected: "Hello, Alice"},
+		{name: "日本語", lang: "ja", key: "greeting", params: map[string]any{"name": "田中"}, expected: "こんにちは 田中 さん"},
+		{name: "キーがなければ既定の言語", lang: "en", key: "only_ja", expected: "日本語のみ"},
+		{name: "どの言語にもなければキーを返す", lang: "en", key: "missing", expected: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, c.Translate(tt.lang, tt.key, tt.params))
+		})
+	}
+}
+
+func TestLoad_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{name: "既定の言語のカタログがない", fsys: fstest.MapFS{"en.json": {Data: []byte(`{}`)}}},
+		{name: "JSONが不正", fsys: fstest.MapFS{"ja.json": {Data: []byte(`{`)}}},
+		{name: "言語タグが不正", fsys: fstest.MapFS{"ja.json": {Data: []byte(`{}`)}, "not a tag.json": {Data: []byte(`{}`)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := i18n.Load(tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package github はGitHub GraphQL APIのクライアントを提供します
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/github/gen"
+)
+
+// Endpoint はGitHub GraphQL APIのエンドポイントです
+const Endpoint = "https://api.github.com/graphql"
+
+// NewClient はトークン認証付きのGitHub GraphQLクライアントを作成します
+// interceptorsは認証ヘッダーの設定後に順に適用されます
+func NewClient(httpClient *http.Client, token string, interceptors ...clientv2.RequestInterceptor) gen.GithubGraphQLClient {
+	authInterceptor := func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return next(ctx, req, gqlInfo, res)
+	}
+	return gen.NewClient(httpClient, Endpoint, nil, append([]clientv2.RequestInterceptor{authInterceptor}, interceptors...)...)
+}
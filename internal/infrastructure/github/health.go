@@ -0,0 +1,26 @@
+package github
+
+import (
+	"context"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/github/gen"
+)
+
+// HealthChecker はGitHub APIに認証済みで到達できるかを確認します
+type HealthChecker struct {
+	client gen.GithubGraphQLClient
+}
+
+// NewHealthChecker は新しいHealthCheckerを作成します
+func NewHealthChecker(client gen.GithubGraphQLClient) *HealthChecker {
+	return &HealthChecker{client: client}
+}
+
+// Check は最も軽量なクエリであるviewerを取得して疎通を確認します
+func (h *HealthChecker) Check(ctx context.Context) (map[string]any, error) {
+	viewer, err := h.client.GetViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"login": viewer.GetViewer().GetLogin()}, nil
+}
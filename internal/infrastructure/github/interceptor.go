@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+const tracerName = "github.com/nansystem/go-ddd/internal/infrastructure/github"
+
+// RequestIDInterceptor はコンテキストのリクエストIDをX-Request-IDヘッダーとして送信します
+// 外部APIへの呼び出しを発行元のリクエストと対応付けるために使います
+func RequestIDInterceptor() clientv2.RequestInterceptor {
+	return func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		if id := requestid.FromContext(ctx); id != "" {
+			req.Header.Set(requestid.Header, id)
+		}
+		return next(ctx, req, gqlInfo, res)
+	}
+}
+
+// TracingInterceptor はGraphQLの操作ごとにクライアントスパンを記録し、traceparentヘッダーを送信します
+// スパン名には操作名を使います
+func TracingInterceptor() clientv2.RequestInterceptor {
+	tracer := otel.Tracer(tracerName)
+
+	return func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		operation := operationName(gqlInfo)
+		ctx, span := tracer.Start(ctx, "GraphQL "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("graphql.operation.name", operation),
+				semconv.ServerAddress(req.URL.Hostname()),
+			),
+		)
+		defer span.End()
+
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		err := next(ctx, req, gqlInfo, res)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+// operationName はメトリクスやスパンに使うGraphQLの操作名を返します
+func operationName(gqlInfo *clientv2.GQLRequestInfo) string {
+	if gqlInfo == nil || gqlInfo.Request == nil || gqlInfo.Request.OperationName == "" {
+		return "unknown"
+	}
+	return gqlInfo.Request.OperationName
+}
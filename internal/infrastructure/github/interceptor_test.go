@@ -0,0 +1,91 @@
+package github_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/github"
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "リクエストIDをヘッダーに設定する",
+			ctx:      requestid.WithID(context.Background(), "req-1"),
+			expected: "req-1",
+		},
+		{
+			name:     "リクエストIDがなければ設定しない",
+			ctx:      context.Background(),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, github.Endpoint, nil)
+			called := false
+			next := func(_ context.Context, req *http.Request, _ *clientv2.GQLRequestInfo, _ any) error {
+				called = true
+				assert.Equal(t, tt.expected, req.Header.Get(requestid.Header))
+				return nil
+			}
+
+			err := github.RequestIDInterceptor()(tt.ctx, req, nil, nil, next)
+
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	req := httptest.NewRequest(http.MethodPost, github.Endpoint, nil)
+	gqlInfo := &clientv2.GQLRequestInfo{Request: &clientv2.Request{OperationName: "GetViewer"}}
+	var traceparent string
+	next := func(_ context.Context, req *http.Request, _ *clientv2.GQLRequestInfo, _ any) error {
+		traceparent = req.Header.Get("traceparent")
+		return nil
+	}
+
+	err := github.TracingInterceptor()(ctx, req, gqlInfo, nil, next)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GraphQL GetViewer", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+	// 送信するtraceparentはGraphQLのスパンを親として指す
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparent)
+}
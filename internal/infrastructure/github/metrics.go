@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+
+	"github.com/nansystem/go-ddd/internal/metrics"
+)
+
+// GitHub APIがレート制限の状態を返すヘッダー
+const (
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResourceHeader  = "X-RateLimit-Resource"
+)
+
+// MetricsInterceptor はGraphQLの操作ごとに呼び出し時間を記録します
+func MetricsInterceptor(m *metrics.Metrics) clientv2.RequestInterceptor {
+	return func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		operation := operationName(gqlInfo)
+		start := time.Now()
+		err := next(ctx, req, gqlInfo, res)
+		m.ObserveGitHubRequest(operation, err, time.Since(start))
+		return err
+	}
+}
+
+// NewRateLimitTransport はレスポンスヘッダーから残りのリクエスト可能数を記録するRoundTripperを作成します
+// インターセプターからはレスポンスヘッダーを参照できないため、HTTPクライアントのTransportとして使います
+func NewRateLimitTransport(base http.RoundTripper, m *metrics.Metrics) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return rateLimitTransport{base: base, metrics: m}
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	metrics *metrics.Metrics
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if remaining, convErr := strconv.Atoi(res.Header.Get(rateLimitRemainingHeader)); convErr == nil {
+		resource := res.Header.Get(rateLimitResourceHeader)
+		if resource == "" {
+			resource = "unknown"
+		}
+		t.metrics.SetGitHubRateLimitRemaining(resource, remaining)
+	}
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package github_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/github"
+	"github.com/nansystem/go-ddd/internal/metrics"
+)
+
+func TestMetricsInterceptor(t *testing.T) {
+	m := metrics.New()
+	req := httptest.NewRequest(http.MethodPost, github.Endpoint, nil)
+	gqlInfo := &clientv2.GQLRequestInfo{Request: &clientv2.Request{OperationName: "GetViewer"}}
+	next := func(_ context.Context, _ *http.Request, _ *clientv2.GQLRequestInfo, _ any) error { return nil }
+
+	err := github.MetricsInterceptor(m)(context.Background(), req, gqlInfo, nil, next)
+
+	require.NoError(t, err)
+	assert.Contains(t, scrape(t, m), `go_ddd_github_request_duration_seconds_count{operation="GetViewer",outcome="success"} 1`)
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Resource", "graphql")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := metrics.New()
+	client := &http.Client{Transport: github.NewRateLimitTransport(nil, m)}
+	res, err := client.Get(server.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	assert.Contains(t, scrape(t, m), `go_ddd_github_rate_limit_remaining{resource="graphql"} 4321`)
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
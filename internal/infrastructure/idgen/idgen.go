@@ -0,0 +1,65 @@
+package idgen
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+// 採番方式の名前
+const (
+	StrategyUUIDv4 = "uuidv4"
+	StrategyUUIDv7 = "uuidv7"
+	StrategyULID   = "ulid"
+)
+
+// UUIDv4Generator はランダムなUUIDv4を採番します
+type UUIDv4Generator struct{}
+
+// Generate は新しいIDを返します
+func (UUIDv4Generator) Generate() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("UUIDv4の生成に失敗しました: %w", err)
+	}
+	return id.String(), nil
+}
+
+// UUIDv7Generator は時刻順に並ぶUUIDv7を採番します
+// 挿入位置が末尾に集まるため、InnoDBの主キーに向いています
+type UUIDv7Generator struct{}
+
+// Generate は新しいIDを返します
+func (UUIDv7Generator) Generate() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("UUIDv7の生成に失敗しました: %w", err)
+	}
+	return id.String(), nil
+}
+
+// ULIDGenerator は時刻順に並ぶULIDを採番します
+type ULIDGenerator struct{}
+
+// Generate は新しいIDを返します
+// ulid.Makeはプロセス内で単調増加し、並行呼び出しにも安全です
+func (ULIDGenerator) Generate() (string, error) {
+	return ulid.Make().String(), nil
+}
+
+// New は採番方式の名前に対応するIDGeneratorを返します
+func New(strategy string) (user.IDGenerator, error) {
+	switch strategy {
+	case StrategyUUIDv4:
+		return UUIDv4Generator{}, nil
+	case StrategyUUIDv7:
+		return UUIDv7Generator{}, nil
+	case StrategyULID:
+		return ULIDGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("未対応のID採番方式です: %s", strategy)
+	}
+}
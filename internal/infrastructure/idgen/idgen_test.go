@@ -0,0 +1,72 @@
+package idgen_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/idgen"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		strategy string
+		validate func(t *testing.T, id string)
+		ordered  bool
+	}{
+		{
+			strategy: idgen.StrategyUUIDv4,
+			validate: func(t *testing.T, id string) {
+				parsed, err := uuid.Parse(id)
+				require.NoError(t, err)
+				assert.Equal(t, uuid.Version(4), parsed.Version())
+			},
+		},
+		{
+			strategy: idgen.StrategyUUIDv7,
+			validate: func(t *testing.T, id string) {
+				parsed, err := uuid.Parse(id)
+				require.NoError(t, err)
+				assert.Equal(t, uuid.Version(7), parsed.Version())
+			},
+			ordered: true,
+		},
+		{
+			strategy: idgen.StrategyULID,
+			validate: func(t *testing.T, id string) {
+				_, err := ulid.ParseStrict(id)
+				require.NoError(t, err)
+			},
+			ordered: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			gen, err := idgen.New(tt.strategy)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, 100)
+			for range 100 {
+				id, err := gen.Generate()
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(id), 36) // users.idはVARCHAR(36)
+				tt.validate(t, id)
+				ids = append(ids, id)
+			}
+
+			if tt.ordered {
+				assert.True(t, sort.StringsAreSorted(ids), "時刻順に採番されること")
+			}
+		})
+	}
+}
+
+func TestNew_UnknownStrategy(t *testing.T) {
+	_, err := idgen.New("serial")
+	assert.Error(t, err)
+}
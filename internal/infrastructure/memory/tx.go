@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"maps"
+)
+
+// txKey はコンテキストがトランザクション内であることを示すキーです
+// 値はトランザクションがロックを保持しているUserRepositoryです
+type txKey struct{}
+
+// TxManager はUserRepositoryに対するusecase.UnitOfWorkの実装です
+// トランザクションの間はリポジトリの書き込みロックを保持するため、トランザクション外の読み書きは終了まで待たされます
+// fnが失敗した場合はDo開始時点の状態に戻します
+type TxManager struct {
+	repo *UserRepository
+}
+
+// NewTxManager は新しいTxManagerを作成します
+func NewTxManager(repo *UserRepository) *TxManager {
+	return &TxManager{repo: repo}
+}
+
+// Do はfnをトランザクション内で実行します
+// 既にトランザクション内で呼ばれた場合は外側のトランザクションに参加します
+// fnの中でリポジトリを使う場合は、fnに渡されたctxを使う必要があります (別のctxではロックの解放を待ち続けます)
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.repo.inTx(ctx) {
+		return fn(ctx)
+	}
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	m.repo.mu.Lock()
+	defer m.repo.mu.Unlock()
+
+	// ロックを保持している間は他から書き込まれないため、開始時点の複製に戻せば変更だけを取り消せる
+	snapshot := maps.Clone(m.repo.users)
+	defer func() {
+		if p := recover(); p != nil {
+			m.repo.users = snapshot
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, m.repo)); err != nil {
+		m.repo.users = snapshot
+		return err
+	}
+	return nil
+}
+
+// inTx はctxがこのリポジトリのロックを保持するトランザクション内かを判定します
+func (r *UserRepository) inTx(ctx context.Context) bool {
+	tx, _ := ctx.Value(txKey{}).(*UserRepository)
+	return tx == r
+}
+
+// lock は書き込みロックを取得し、解放する関数を返します
+// トランザクション内ではTxManagerがロックを保持しているため、何もしません
+func (r *UserRepository) lock(ctx context.Context) (unlock func()) {
+	if r.inTx(ctx) {
+		return func() {}
+	}
+	r.mu.Lock()
+	return r.mu.Unlock
+}
+
+// rlock は読み込みロックを取得し、解放する関数を返します
+// トランザクション内ではTxManagerがロックを保持しているため、何もしません
+func (r *UserRepository) rlock(ctx context.Context) (unlock func()) {
+	if r.inTx(ctx) {
+		return func() {}
+	}
+	r.mu.RLock()
+	return r.mu.RUnlock
+}
@@ -0,0 +1,215 @@
+// Package memory はプロセスのメモリ上にデータを保持するリポジトリを提供します
+// DBを用意せずにテストやローカル開発でアプリケーションを動かすために使います
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+// userRecord は保存されたユーザーとDBのcreated_atに相当する作成日時です
+type userRecord struct {
+	user      user.User
+	createdAt time.Time
+}
+
+// UserRepository はuser.Repositoryのメモリ実装です
+// 複数のゴルーチンから同時に使用できます
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[string]userRecord
+}
+
+// NewUserRepository は空のUserRepositoryを作成します
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: map[string]userRecord{}}
+}
+
+func (r *UserRepository) GetUsers(ctx context.Context, query user.Query) (*user.Page, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	var after *userRecord
+	if query.After != nil {
+		var err error
+		after, err = cursorRecord(query.After)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unlock := r.rlock(ctx)
+	records := make([]userRecord, 0, len(r.users))
+	for _, rec := range r.users {
+		if matches(rec, query) && (after == nil || compareRecords(rec, *after, query.Sort) > 0) {
+			records = append(records, rec)
+		}
+	}
+	unlock()
+
+	slices.SortFunc(records, func(a, b userRecord) int {
+		return compareRecords(a, b, query.Sort)
+	})
+
+	page := &user.Page{Users: []*user.User{}}
+	for i, rec := range records {
+		if i == query.Limit {
+			last := records[i-1]
+			page.NextCursor = user.NewCursor(query.Sort, &last.user, last.createdAt)
+			break
+		}
+		u := rec.user
+		page.Users = append(page.Users, &u)
+	}
+	return page, nil
+}
+
+// cursorRecord はカーソルが指す位置を比較用のレコードに変換します
+func cursorRecord(c *user.Cursor) (*userRecord, error) {
+	rec := &userRecord{user: user.User{ID: c.ID, Name: c.Key}}
+	if c.Sort.Field == user.SortByCreatedAt {
+		createdAt, err := c.CreatedAt()
+		if err != nil {
+			return nil, err
+		}
+		rec.createdAt = createdAt
+	}
+	return rec, nil
+}
+
+// matches はレコードが検索条件の絞り込みを満たすかを判定します
+func matches(rec userRecord, query user.Query) bool {
+	if query.NamePrefix != "" && !strings.HasPrefix(rec.user.Name, query.NamePrefix) {
+		return false
+	}
+	if !query.CreatedFrom.IsZero() && rec.createdAt.Before(query.CreatedFrom) {
+		return false
+	}
+	if !query.CreatedTo.IsZero() && !rec.createdAt.Before(query.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// compareRecords は並び順に従って2つのレコードを比較します
+// SQLの実装と同様に、同じ値の場合はIDで順序を決めます
+func compareRecords(a, b userRecord, sort user.Sort) int {
+	var c int
+	if sort.Field == user.SortByCreatedAt {
+		c = a.createdAt.Compare(b.createdAt)
+	} else {
+		c = strings.Compare(a.user.Name, b.user.Name)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.user.ID, b.user.ID)
+	}
+	if sort.Desc {
+		return -c
+	}
+	return c
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*user.User, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	defer r.rlock(ctx)()
+
+	rec, ok := r.users[id]
+	if !ok {
+		return nil, domainerror.NewNotFoundError("User", id)
+	}
+	u := rec.user
+	return &u, nil
+}
+
+// GetUserByIDForUpdate はGetUserByIDと同じです
+// TxManagerはトランザクションを直列に実行するため、行ごとのロックは不要です
+func (r *UserRepository) GetUserByIDForUpdate(ctx context.Context, id string) (*user.User, error) {
+	return r.GetUserByID(ctx, id)
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, u *user.User) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	defer r.lock(ctx)()
+
+	if _, ok := r.users[u.ID]; ok {
+		return domainerror.NewDuplicateEntryError(u.ID, u.Name)
+	}
+	if r.emailTaken(u) {
+		return domainerror.NewDuplicateEmailError(u.Email.String())
+	}
+	// モノトニック時刻を取り除き、カーソルから復元した時刻と比較できるようにする
+	r.users[u.ID] = userRecord{user: *u, createdAt: time.Now().UTC().Round(0)}
+	return nil
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, u *user.User) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	defer r.lock(ctx)()
+
+	rec, ok := r.users[u.ID]
+	if !ok {
+		return domainerror.NewNotFoundError("User", u.ID)
+	}
+	if r.emailTaken(u) {
+		return domainerror.NewDuplicateEmailError(u.Email.String())
+	}
+	rec.user = *u
+	r.users[u.ID] = rec
+	return nil
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	defer r.lock(ctx)()
+
+	if _, ok := r.users[id]; !ok {
+		return domainerror.NewNotFoundError("User", id)
+	}
+	delete(r.users, id)
+	return nil
+}
+
+// emailTaken はu以外のユーザーが同じメールアドレスを使用しているかを判定します
+// 呼び出し側でロックを取得している必要があります
+func (r *UserRepository) emailTaken(u *user.User) bool {
+	for id, rec := range r.users {
+		if id != u.ID && rec.user.Email == u.Email {
+			return true
+		}
+	}
+	return false
+}
+
+// contextError はコンテキストが終了している場合にSQL実装と同じドメインエラーを返します
+func contextError(ctx context.Context) error {
+	err := ctx.Err()
+	switch err {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return fmt.Errorf("%w: %w", domainerror.ErrTimeout, err)
+	default:
+		return fmt.Errorf("%w: %w", domainerror.ErrCanceled, err)
+	}
+}
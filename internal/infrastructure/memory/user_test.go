@@ -0,0 +1,182 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/user/usertest"
+	"github.com/nansystem/go-ddd/internal/infrastructure/memory"
+)
+
+func TestUserRepository(t *testing.T) {
+	usertest.RunRepositoryContract(t, func(t *testing.T) user.Repository {
+		return memory.NewUserRepository()
+	})
+}
+
+func TestUserRepository_Concurrent(t *testing.T) {
+	repo := memory.NewUserRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u := newUser(t, fmt.Sprintf("u%02d", i), fmt.Sprintf("u%02d@example.com", i))
+			assert.NoError(t, repo.CreateUser(ctx, u))
+			_, err := repo.GetUsers(ctx, user.NewQuery())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	query := user.NewQuery()
+	query.Limit = user.MaxLimit
+	page, err := repo.GetUsers(ctx, query)
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 50)
+}
+
+func TestUserRepository_ContextCanceled(t *testing.T) {
+	repo := memory.NewUserRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.GetUserByID(ctx, "u1")
+	assert.ErrorIs(t, err, domainerror.ErrCanceled)
+}
+
+func TestTxManager_Do(t *testing.T) {
+	errApp := errors.New("アプリケーションエラー")
+
+	tests := []struct {
+		name        string
+		fn          func(repo *memory.UserRepository, uow *memory.TxManager) func(ctx context.Context) error
+		expectedErr error
+		expectedIDs []string
+	}{
+		{
+			name: "成功: 変更が反映される",
+			fn: func(repo *memory.UserRepository, _ *memory.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com"))
+				}
+			},
+			expectedIDs: []string{"u1", "u2"},
+		},
+		{
+			name: "失敗: エラー時は開始時点の状態に戻る",
+			fn: func(repo *memory.UserRepository, _ *memory.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.DeleteUser(ctx, "u1"); err != nil {
+						return err
+					}
+					if err := repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com")); err != nil {
+						return err
+					}
+					return errApp
+				}
+			},
+			expectedErr: errApp,
+			expectedIDs: []string{"u1"},
+		},
+		{
+			name: "失敗: ネストしたDoのエラーで外側ごと戻る",
+			fn: func(repo *memory.UserRepository, uow *memory.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com")); err != nil {
+						return err
+					}
+					return uow.Do(ctx, func(ctx context.Context) error {
+						return errApp
+					})
+				}
+			},
+			expectedErr: errApp,
+			expectedIDs: []string{"u1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewUserRepository()
+			require.NoError(t, repo.CreateUser(ctx, newUser(t, "u1", "u1@example.com")))
+			uow := memory.NewTxManager(repo)
+
+			err := uow.Do(ctx, tt.fn(repo, uow))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			page, err := repo.GetUsers(ctx, user.NewQuery())
+			require.NoError(t, err)
+			ids := []string{}
+			for _, u := range page.Users {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestTxManager_Do_ConcurrentWriteSurvivesRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewUserRepository()
+	require.NoError(t, repo.CreateUser(ctx, newUser(t, "u1", "u1@example.com")))
+	uow := memory.NewTxManager(repo)
+	errApp := errors.New("アプリケーションエラー")
+
+	var wg sync.WaitGroup
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := repo.CreateUser(txCtx, newUser(t, "u2", "u2@example.com")); err != nil {
+			return err
+		}
+
+		// トランザクション外からの書き込みを同時に行う
+		done := make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done)
+			assert.NoError(t, repo.CreateUser(ctx, newUser(t, "u3", "u3@example.com")))
+		}()
+		select {
+		case <-done:
+		case <-time.After(50 * time.Millisecond):
+		}
+		return errApp
+	})
+	require.ErrorIs(t, err, errApp)
+	wg.Wait()
+
+	// ロールバックで取り消されるのはトランザクション内の変更だけ
+	page, err := repo.GetUsers(ctx, user.NewQuery())
+	require.NoError(t, err)
+	ids := []string{}
+	for _, u := range page.Users {
+		ids = append(ids, u.ID)
+	}
+	assert.Equal(t, []string{"u1", "u3"}, ids)
+}
+
+func newUser(t *testing.T, id, email string) *user.User {
+	t.Helper()
+
+	e, err := user.NewEmail(email)
+	require.NoError(t, err)
+	return user.NewUser(id, "名前", e)
+}
@@ -3,7 +3,7 @@ package mysql
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	// MySQLドライバを初期化のために必要
@@ -17,11 +17,13 @@ type DBConfig struct {
 	Host     string
 	Port     string
 	DBName   string
+	// QueryTimeout はクエリ1回あたりの実行期限です (0の場合はリクエストの期限のみに従います)
+	QueryTimeout time.Duration
 }
 
 // NewConnection は新しいデータベース接続を作成します
 func NewConnection(config DBConfig) (*sql.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=Asia%%2FTokyo",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=Asia%%2FTokyo&clientFoundRows=true",
 		config.User, config.Password, config.Host, config.Port, config.DBName)
 
 	db, err := sql.Open("mysql", dsn)
@@ -39,6 +41,6 @@ func NewConnection(config DBConfig) (*sql.DB, error) {
 		return nil, fmt.Errorf("データベース接続テストエラー: %w", err)
 	}
 
-	log.Println("データベースに接続しました")
+	slog.Info("データベースに接続しました", "driver", "mysql", "host", config.Host, "database", config.DBName)
 	return db, nil
 }
@@ -0,0 +1,47 @@
+// Package migration はMySQLのスキーママイグレーションを提供します
+// MySQLはDDLが暗黙的にコミットされるため、適用済みバージョンの管理とロックをMigratorが行います
+package migration
+
+import (
+	"embed"
+	"strings"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+// Files はこのパッケージに埋め込まれたマイグレーションファイルです
+//
+//go:embed sql/*.sql
+var Files embed.FS
+
+// Migrations は埋め込まれたマイグレーションファイルをバージョン順に読み込みます
+func Migrations() ([]migration.Migration, error) {
+	return migration.Load(Files, "sql")
+}
+
+// splitStatements はSQLファイルを文単位に分割します
+// go-sql-driverはmultiStatementsを有効にしない限り1回のExecで1文しか実行できないため、
+// 行末のセミコロンを文の区切りとして扱います (行頭が -- のコメント行は除外します)
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			stmt := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
+			statements = append(statements, stmt)
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
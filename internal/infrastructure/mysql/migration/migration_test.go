@@ -0,0 +1,51 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, mig := range migrations {
+		assert.NotEmpty(t, mig.Up, mig.Name)
+		assert.NotEmpty(t, mig.Down, mig.Name)
+		if i > 0 {
+			assert.Greater(t, mig.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestMigrations_CreateUsersIsIdempotent(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	// 以前のinitdbで作成したusersテーブルが残っていても適用できること
+	statements := splitStatements(migrations[0].Up)
+	assert.True(t, strings.HasPrefix(statements[0], "CREATE TABLE IF NOT EXISTS users"), statements[0])
+	for _, stmt := range statements {
+		assert.False(t, strings.HasPrefix(stmt, "CREATE INDEX"), "インデックスは有無を確認してから作成する: %s", stmt)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- ユーザーテーブル
+CREATE TABLE t (
+    c INT
+);
+
+INSERT INTO t (c) VALUES (1);
+UPDATE t SET c = 2`
+
+	assert.Equal(t, []string{
+		"CREATE TABLE t (\n    c INT\n)",
+		"INSERT INTO t (c) VALUES (1)",
+		"UPDATE t SET c = 2",
+	}, splitStatements(script))
+}
@@ -0,0 +1,278 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+// 同時実行を防ぐためのロック名と待機秒数
+const (
+	lockName           = "go_ddd_schema_migrations"
+	lockTimeoutSeconds = 10
+)
+
+// MySQLのエラー番号
+const errNoSuchTable = 1146
+
+// ErrLocked は他のプロセスがマイグレーション中でロックを取得できなかった場合のエラーです
+var ErrLocked = errors.New("他のプロセスがマイグレーションを実行中です")
+
+// Status はマイグレーション1件の適用状況です
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Missing は適用済みだがファイルが存在しないバージョンであることを示します
+	Missing bool
+}
+
+// Migrator はschema_migrationsテーブルで適用済みバージョンを管理しながらマイグレーションを実行します
+type Migrator struct {
+	db         *sql.DB
+	migrations []migration.Migration
+	// DryRun がtrueの場合、SQLを実行せずOutに出力します
+	DryRun bool
+	// Out は実行内容の出力先です
+	Out io.Writer
+}
+
+// NewMigrator は新しいMigratorを作成します
+func NewMigrator(db *sql.DB, migrations []migration.Migration, out io.Writer) *Migrator {
+	return &Migrator{db: db, migrations: migrations, Out: out}
+}
+
+// Up は未適用のマイグレーションをすべて適用し、適用したものを返します
+func (m *Migrator) Up(ctx context.Context) ([]migration.Migration, error) {
+	var applied []migration.Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := versions[mig.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, mig, true); err != nil {
+				return err
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down は適用済みのマイグレーションを新しい順にn件取り消し、取り消したものを返します
+func (m *Migrator) Down(ctx context.Context, n int) ([]migration.Migration, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("取り消す件数は1以上を指定してください: %d", n)
+	}
+
+	var reverted []migration.Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		targets, err := m.lastApplied(ctx, conn, n)
+		if err != nil {
+			return err
+		}
+		for _, mig := range targets {
+			if err := m.apply(ctx, conn, mig, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Redo は最後に適用したマイグレーションを取り消してから再適用します
+func (m *Migrator) Redo(ctx context.Context) (*migration.Migration, error) {
+	var redone *migration.Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		targets, err := m.lastApplied(ctx, conn, 1)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return errors.New("適用済みのマイグレーションがありません")
+		}
+		mig := targets[0]
+		if err := m.apply(ctx, conn, mig, false); err != nil {
+			return err
+		}
+		if err := m.apply(ctx, conn, mig, true); err != nil {
+			return err
+		}
+		redone = &mig
+		return nil
+	})
+	return redone, err
+}
+
+// Status はすべてのマイグレーションの適用状況をバージョン順に返します
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("データベース接続の取得に失敗しました: %w", err)
+	}
+	defer conn.Close()
+
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	known := map[int64]bool{}
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+		appliedAt, ok := versions[mig.Version]
+		statuses = append(statuses, Status{Version: mig.Version, Name: mig.Name, Applied: ok, AppliedAt: appliedAt})
+	}
+	for version, appliedAt := range versions {
+		if !known[version] {
+			statuses = append(statuses, Status{Version: version, Applied: true, AppliedAt: appliedAt, Missing: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// withLock はGET_LOCKで排他を取ったうえでfnを実行します
+// GET_LOCKはセッション単位のロックのため、専用の接続を確保してその接続で処理を行います
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("データベース接続の取得に失敗しました: %w", err)
+	}
+	defer conn.Close()
+
+	// dry-runではスキーマを変更しないため、ロックも管理テーブルの作成も行わない
+	if m.DryRun {
+		return fn(conn)
+	}
+
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutSeconds).Scan(&got); err != nil {
+		return fmt.Errorf("ロックの取得に失敗しました: %w", err)
+	}
+	if !got.Valid || got.Int64 != 1 {
+		return ErrLocked
+	}
+	defer func() {
+		// 元のctxがキャンセルされていても確実に解放する
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", lockName)
+	}()
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`)
+	if err != nil {
+		return fmt.Errorf("schema_migrationsテーブルの作成に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// appliedVersions は適用済みのバージョンと適用日時を返します
+// 管理テーブルがまだ存在しない場合は未適用として扱います
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoSuchTable {
+			return map[int64]time.Time{}, nil
+		}
+		return nil, fmt.Errorf("適用済みバージョンの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	versions := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("適用済みバージョンの取得に失敗しました: %w", err)
+		}
+		versions[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("適用済みバージョンの取得に失敗しました: %w", err)
+	}
+	return versions, nil
+}
+
+// lastApplied は適用済みのマイグレーションを新しい順に最大n件返します
+func (m *Migrator) lastApplied(ctx context.Context, conn *sql.Conn, n int) ([]migration.Migration, error) {
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []migration.Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(targets) < n; i-- {
+		if _, ok := versions[m.migrations[i].Version]; ok {
+			targets = append(targets, m.migrations[i])
+		}
+	}
+	return targets, nil
+}
+
+// apply はマイグレーションのupまたはdownを実行し、schema_migrationsを更新します
+// MySQLのDDLは暗黙的にコミットされるためトランザクションは使いません
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig migration.Migration, up bool) error {
+	direction, script := "up", mig.Up
+	if !up {
+		direction, script = "down", mig.Down
+	}
+
+	if m.Out != nil {
+		fmt.Fprintf(m.Out, "-- %d_%s (%s)\n", mig.Version, mig.Name, direction)
+	}
+
+	for _, stmt := range splitStatements(script) {
+		if m.DryRun {
+			if m.Out != nil {
+				fmt.Fprintf(m.Out, "%s;\n", stmt)
+			}
+			continue
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("マイグレーション %d_%s (%s) の実行に失敗しました: %w", mig.Version, mig.Name, direction, err)
+		}
+	}
+
+	if m.DryRun {
+		return nil
+	}
+
+	var err error
+	if up {
+		_, err = conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", mig.Version, mig.Name)
+	} else {
+		_, err = conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", mig.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("schema_migrationsの更新に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql/migration"
+	sqlmigration "github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+var testMigrations = []sqlmigration.Migration{
+	{Version: 1, Name: "create_t", Up: "CREATE TABLE t (c INT);", Down: "DROP TABLE t;"},
+	{Version: 2, Name: "add_d", Up: "ALTER TABLE t ADD d INT;", Down: "ALTER TABLE t DROP d;"},
+}
+
+func TestMigrator_Up(t *testing.T) {
+	db, m, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer db.Close()
+
+	m.ExpectQuery("SELECT GET_LOCK").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+	m.ExpectExec("ALTER TABLE t ADD d INT").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "add_d").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.ExpectExec("SELECT RELEASE_LOCK").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := migration.NewMigrator(db, testMigrations, nil)
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, int64(2), applied[0].Version)
+	assert.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestMigrator_Down_Locked(t *testing.T) {
+	db, m, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// 他のプロセスがロックを保持している場合、GET_LOCKはタイムアウトして0を返す
+	m.ExpectQuery("SELECT GET_LOCK").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+
+	migrator := migration.NewMigrator(db, testMigrations, nil)
+	_, err = migrator.Down(context.Background(), 1)
+	assert.ErrorIs(t, err, migration.ErrLocked)
+	assert.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	db, m, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// dry-runではロックも書き込みも行わず、適用状況の参照のみ行う
+	m.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()).AddRow(2, time.Now()))
+
+	var out bytes.Buffer
+	migrator := migration.NewMigrator(db, testMigrations, &out)
+	migrator.DryRun = true
+
+	reverted, err := migrator.Down(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Len(t, reverted, 2)
+	assert.Equal(t, "-- 2_add_d (down)\nALTER TABLE t DROP d;\n-- 1_create_t (down)\nDROP TABLE t;\n", out.String())
+	assert.NoError(t, m.ExpectationsWereMet())
+}
@@ -0,0 +1,30 @@
+-- 以前のdocker/mysql/initdb.d/01_schema.sqlで作成したusersテーブルが残っている環境でも適用できるよう、
+-- 既存のテーブルとインデックスはそのまま使い、足りないものだけを作成する
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- MySQLにはCREATE INDEX IF NOT EXISTSがないため、information_schemaでインデックスの有無を確認してから作成する
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.statistics
+     WHERE table_schema = DATABASE() AND table_name = 'users' AND index_name = 'idx_users_name_id') = 0,
+    'CREATE INDEX idx_users_name_id ON users (name, id)',
+    'DO 0'
+);
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.statistics
+     WHERE table_schema = DATABASE() AND table_name = 'users' AND index_name = 'idx_users_created_at_id') = 0,
+    'CREATE INDEX idx_users_created_at_id ON users (created_at, id)',
+    'DO 0'
+);
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,40 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/user/usertest"
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql"
+	"github.com/nansystem/go-ddd/internal/infrastructure/mysql/migration"
+)
+
+// TestUserRepository はTEST_MYSQL_DSNが設定されている場合のみ実行します
+// 例: TEST_MYSQL_DSN="ddduser:dddpass@tcp(localhost:13306)/go_ddd_test?parseTime=true&clientFoundRows=true"
+func TestUserRepository(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSNが設定されていないためスキップします")
+	}
+	db, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.Ping())
+
+	migrations, err := migration.Migrations()
+	require.NoError(t, err)
+	_, err = migration.NewMigrator(db, migrations, io.Discard).Up(context.Background())
+	require.NoError(t, err)
+
+	usertest.RunRepositoryContract(t, func(t *testing.T) user.Repository {
+		_, err := db.Exec("DELETE FROM users")
+		require.NoError(t, err)
+		return mysql.NewUserRepository(db, 0)
+	})
+}
@@ -0,0 +1,70 @@
+// Package postgres はPostgreSQLを使った永続化を提供します
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+
+	// PostgreSQLドライバ (pgx) を初期化のために必要
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// DBConfig はデータベース接続の設定を保持します
+type DBConfig struct {
+	User     string
+	Password string
+	Host     string
+	Port     string
+	DBName   string
+	// SSLMode はlibpqのsslmodeです (disable, require, verify-full など)
+	SSLMode string
+	// QueryTimeout はクエリ1回あたりの実行期限です (0の場合はリクエストの期限のみに従います)
+	QueryTimeout time.Duration
+}
+
+// DSN は接続文字列を返します
+func (c DBConfig) DSN() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   net.JoinHostPort(c.Host, c.Port),
+		Path:   "/" + c.DBName,
+	}
+	q := url.Values{}
+	if c.SSLMode != "" {
+		q.Set("sslmode", c.SSLMode)
+	}
+	// created_atの比較や表示がサーバーのタイムゾーンに依存しないようにする
+	q.Set("timezone", "UTC")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// NewConnection は新しいデータベース接続を作成します
+// 接続できない場合はdomainerror.ErrConnectionを返します
+func NewConnection(config DBConfig) (*sql.DB, error) {
+	db, err := sql.Open("pgx", config.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainerror.ErrConnection, err)
+	}
+
+	// 接続確認とコネクションプールの設定
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(25)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// 接続テスト
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: %w", domainerror.ErrConnection, err)
+	}
+
+	slog.Info("データベースに接続しました", "driver", "postgres", "host", config.Host, "database", config.DBName)
+	return db, nil
+}
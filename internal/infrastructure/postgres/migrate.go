@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+// migrationFiles はPostgreSQL用のマイグレーションファイルです
+// ファイル名の形式はmigrationパッケージと共通です
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID は同時実行を防ぐためのアドバイザリロックのキーです
+const migrationLockID = 7243019581
+
+// Migrate は未適用のマイグレーションをすべて適用し、適用したものを返します
+// 複数のプロセスが同時に起動しても、アドバイザリロックにより1つずつ適用されます
+func Migrate(ctx context.Context, db *sql.DB) ([]migration.Migration, error) {
+	migrations, err := migration.Load(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return sqldb.Migrate(ctx, db, sqldb.PostgreSQL, migrations, fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", migrationLockID))
+}
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+// NewUserRepository はPostgreSQL用のUserRepositoryを作成します
+// 一意制約違反 (SQLSTATE 23505) はdomainerrorの重複エラーに変換されます
+// queryTimeoutが0より大きい場合、各クエリにその期限を設定します
+func NewUserRepository(db *sql.DB, queryTimeout time.Duration) *sqldb.UserRepository {
+	return sqldb.NewUserRepository(db, sqldb.PostgreSQL, queryTimeout)
+}
+
+// NewTxManager はPostgreSQL用のTxManagerを作成します
+// シリアライゼーション失敗 (40001) とデッドロック (40P01) の場合はトランザクションを再実行します
+func NewTxManager(db *sql.DB) *sqldb.TxManager {
+	return sqldb.NewTxManager(db, sqldb.PostgreSQL)
+}
@@ -0,0 +1,33 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+// commentingExecutor はクエリの先頭にリクエストIDのコメントを付与します
+// スロークエリログやSHOW PROCESSLISTに表示されるクエリから、発行元のリクエストを特定できるようにします
+// Dialect.RequestCommentがtrueの方言でのみ使います
+type commentingExecutor struct {
+	executor
+}
+
+func (e commentingExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return e.executor.QueryContext(ctx, withRequestComment(ctx, query), args...)
+}
+
+func (e commentingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return e.executor.ExecContext(ctx, withRequestComment(ctx, query), args...)
+}
+
+// withRequestComment はコンテキストにリクエストIDがあればクエリの先頭にコメントとして付与します
+// コメントを閉じる文字列などを埋め込まれないよう、形式を満たすIDのみ使います
+func withRequestComment(ctx context.Context, query string) string {
+	id := requestid.FromContext(ctx)
+	if id == "" || !requestid.Valid(id) {
+		return query
+	}
+	return "/* request_id=" + id + " */ " + query
+}
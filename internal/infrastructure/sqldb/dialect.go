@@ -0,0 +1,104 @@
+// Package sqldb はdatabase/sqlを使ったリポジトリの実装を提供します
+// データベースごとの差異はDialectに閉じ込め、同じリポジトリのコードでMySQL、PostgreSQL、SQLiteを扱います
+package sqldb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// Dialect はデータベースごとのSQLの差異を吸収します
+type Dialect interface {
+	// Name は方言の名前です (mysql, postgres, sqlite)
+	Name() string
+	// Rebind は ? で書かれたプレースホルダをこの方言の形式に変換します
+	Rebind(query string) string
+	// RequestComment はクエリにリクエストIDのコメントを付与するかを返します
+	// クエリの文字列が変わるとステートメントキャッシュが効かなくなるドライバでは付与しません
+	RequestComment() bool
+	// Upsert は主キーまたは一意キーが衝突した場合に更新するINSERT文を返します
+	Upsert(table string, columns, conflictColumns, updateColumns []string) string
+	// ForUpdate はSELECTで読み込んだ行をトランザクションの終了までロックする句を返します
+	// 行ロックを持たない方言は空文字列を返し、トランザクションの開始時に取得するロックで代替します
+	ForUpdate() string
+	// Limit は取得する件数を指定する句を返します (プレースホルダ1つを含みます)
+	// 一覧はキーセットページネーションで取得するため、開始位置は指定しません
+	Limit() string
+	// LimitOffset は件数と開始位置を指定する句を返します (プレースホルダ2つを含みます)
+	LimitOffset() string
+	// BindTime は日時をクエリパラメータとして渡す形式に変換します
+	BindTime(t time.Time) any
+	// DuplicateKey は一意制約違反のエラーであれば、違反したキー (インデックス名やカラム名) を返します
+	DuplicateKey(err error) (key string, ok bool)
+	// IsRetryable はトランザクションを再実行すれば成功し得るエラー (デッドロックなど) かを判定します
+	IsRetryable(err error) bool
+	// Classify はドライバのエラーを分類し、domainerrorのErrConnection、ErrTransaction、ErrQueryのいずれかを返します
+	// 分類できない場合はnilを返します
+	Classify(err error) error
+}
+
+// classifyCommon はドライバによらない接続・トランザクションのエラーを分類します
+func classifyCommon(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone), errors.As(err, &netErr):
+		return domainerror.ErrConnection
+	case errors.Is(err, sql.ErrTxDone):
+		return domainerror.ErrTransaction
+	default:
+		return nil
+	}
+}
+
+// questionRebind は ? のまま扱う方言のRebindです
+func questionRebind(query string) string {
+	return query
+}
+
+// dollarRebind は ? を $1, $2, ... に変換します
+// 文字列リテラル内の ? は変換しません
+func dollarRebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	inQuote := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			b.WriteByte(c)
+		case c == '?' && !inQuote:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// insertStatement はINSERT文の共通部分を組み立てます
+func insertStatement(table string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders + ")"
+}
+
+// onConflictUpsert はPostgreSQLとSQLiteに共通する ON CONFLICT 形式のUpsertです
+func onConflictUpsert(table string, columns, conflictColumns, updateColumns []string) string {
+	sets := make([]string, 0, len(updateColumns))
+	for _, c := range updateColumns {
+		sets = append(sets, c+" = excluded."+c)
+	}
+	return insertStatement(table, columns) +
+		" ON CONFLICT (" + strings.Join(conflictColumns, ", ") + ") DO UPDATE SET " + strings.Join(sets, ", ")
+}
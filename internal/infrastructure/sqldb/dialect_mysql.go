@@ -0,0 +1,111 @@
+package sqldb
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// MySQLのエラー番号
+const (
+	mysqlErrDupEntry        = 1062
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrLockDeadlock    = 1213
+)
+
+// mysqlErrorKinds はエラー番号ごとの分類です
+var mysqlErrorKinds = map[uint16]error{
+	1040: domainerror.ErrConnection,  // ER_CON_COUNT_ERROR (接続数の上限)
+	1045: domainerror.ErrConnection,  // ER_ACCESS_DENIED_ERROR
+	1053: domainerror.ErrConnection,  // ER_SERVER_SHUTDOWN
+	2002: domainerror.ErrConnection,  // CR_CONNECTION_ERROR
+	2003: domainerror.ErrConnection,  // CR_CONN_HOST_ERROR
+	2006: domainerror.ErrConnection,  // CR_SERVER_GONE_ERROR
+	2013: domainerror.ErrConnection,  // CR_SERVER_LOST
+	1205: domainerror.ErrTransaction, // ER_LOCK_WAIT_TIMEOUT
+	1213: domainerror.ErrTransaction, // ER_LOCK_DEADLOCK
+	1048: domainerror.ErrQuery,       // ER_BAD_NULL_ERROR
+	1054: domainerror.ErrQuery,       // ER_BAD_FIELD_ERROR
+	1062: domainerror.ErrQuery,       // ER_DUP_ENTRY
+	1064: domainerror.ErrQuery,       // ER_PARSE_ERROR (構文エラー)
+	1146: domainerror.ErrQuery,       // ER_NO_SUCH_TABLE
+	1264: domainerror.ErrQuery,       // ER_WARN_DATA_OUT_OF_RANGE
+	1406: domainerror.ErrQuery,       // ER_DATA_TOO_LONG
+	1451: domainerror.ErrQuery,       // ER_ROW_IS_REFERENCED_2 (外部キー制約違反)
+	1452: domainerror.ErrQuery,       // ER_NO_REFERENCED_ROW_2 (外部キー制約違反)
+}
+
+// MySQL はMySQL 8.0向けの方言です
+var MySQL Dialect = mysqlDialect{}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Rebind(query string) string { return questionRebind(query) }
+
+// RequestComment はスロークエリログやSHOW PROCESSLISTから発行元のリクエストを特定できるよう、コメントを付与します
+// go-sql-driverはプレースホルダのあるクエリを都度プリペアするため、コメントによる性能の低下はありません
+func (mysqlDialect) RequestComment() bool { return true }
+
+// Upsert はON DUPLICATE KEY UPDATEを使います
+// 一意キーはテーブル定義で決まるため、conflictColumnsは使いません
+// VALUES()関数は8.0.20で非推奨になったため、行エイリアスで新しい値を参照します
+func (mysqlDialect) Upsert(table string, columns, _, updateColumns []string) string {
+	sets := make([]string, 0, len(updateColumns))
+	for _, c := range updateColumns {
+		sets = append(sets, c+" = new."+c)
+	}
+	return insertStatement(table, columns) + " AS new ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+func (mysqlDialect) ForUpdate() string { return " FOR UPDATE" }
+
+func (mysqlDialect) Limit() string { return "LIMIT ?" }
+
+func (mysqlDialect) LimitOffset() string { return "LIMIT ? OFFSET ?" }
+
+func (mysqlDialect) BindTime(t time.Time) any { return t }
+
+// DuplicateKey は1062エラーのメッセージからキー名を取り出します
+// MySQL 8.0では "for key 'users.email'"、5.7以前では "for key 'email'" となります
+func (mysqlDialect) DuplicateKey(err error) (string, bool) {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlErrDupEntry {
+		return "", false
+	}
+	_, key, found := strings.Cut(mysqlErr.Message, "for key '")
+	if !found {
+		return "", true
+	}
+	key = strings.TrimSuffix(key, "'")
+	if _, column, ok := strings.Cut(key, "."); ok {
+		key = column
+	}
+	return key, true
+}
+
+func (mysqlDialect) IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+}
+
+// Classify はエラー番号からエラーを分類します
+// サーバーのエラー番号を持たない接続断 (mysql.ErrInvalidConn) は接続エラーとして扱います
+func (mysqlDialect) Classify(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErrorKinds[mysqlErr.Number]
+	}
+	if errors.Is(err, mysql.ErrInvalidConn) {
+		return domainerror.ErrConnection
+	}
+	return classifyCommon(err)
+}
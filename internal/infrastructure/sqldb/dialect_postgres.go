@@ -0,0 +1,83 @@
+package sqldb
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// PostgreSQLのSQLSTATE
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// PostgreSQL はPostgreSQL向けの方言です
+var PostgreSQL Dialect = postgresDialect{}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) Rebind(query string) string { return dollarRebind(query) }
+
+// RequestComment はpgxのステートメントキャッシュがクエリの文字列をキーにするため、コメントを付与しません
+// リクエストごとに異なるコメントを付けると、毎回プリペアし直すことになります
+func (postgresDialect) RequestComment() bool { return false }
+
+func (postgresDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return onConflictUpsert(table, columns, conflictColumns, updateColumns)
+}
+
+func (postgresDialect) ForUpdate() string { return " FOR UPDATE" }
+
+func (postgresDialect) Limit() string { return "LIMIT ?" }
+
+func (postgresDialect) LimitOffset() string { return "LIMIT ? OFFSET ?" }
+
+func (postgresDialect) BindTime(t time.Time) any { return t }
+
+// DuplicateKey はSQLSTATE 23505のエラーから制約名を返します
+// メッセージはlc_messagesの設定で翻訳されるため、解析せずにサーバーが返すフィールドを使います
+func (postgresDialect) DuplicateKey(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return "", false
+	}
+	return pgErr.ConstraintName, true
+}
+
+func (postgresDialect) IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+// Classify はSQLSTATEのクラス (先頭2文字) からエラーを分類します
+func (postgresDialect) Classify(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return classifyCommon(err)
+	}
+	state := pgErr.Code
+	switch {
+	// 08: 接続例外, 57P01-57P03: サーバーの停止・起動中
+	case strings.HasPrefix(state, "08"), state == "57P01", state == "57P02", state == "57P03":
+		return domainerror.ErrConnection
+	// 40: トランザクションのロールバック, 55P03: ロックを取得できない
+	case strings.HasPrefix(state, "40"), state == "55P03":
+		return domainerror.ErrTransaction
+	// 22: データ例外, 23: 整合性制約違反, 42: 構文エラーまたはアクセス規則違反
+	case strings.HasPrefix(state, "22"), strings.HasPrefix(state, "23"), strings.HasPrefix(state, "42"):
+		return domainerror.ErrQuery
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package sqldb
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// SQLiteの結果コード (拡張コードの下位8ビットが基本コード)
+const (
+	sqliteError      = 1
+	sqliteBusy       = 5
+	sqliteLocked     = 6
+	sqliteIOErr      = 10
+	sqliteCantOpen   = 14
+	sqliteTooBig     = 18
+	sqliteConstraint = 19
+	sqliteMismatch   = 20
+	sqliteRange      = 25
+)
+
+// sqliteTimeFormat はCURRENT_TIMESTAMPと同じ形式です
+// 日時は文字列として比較されるため、保存時と検索時で形式を揃えます
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// SQLite はSQLite向けの方言です
+var SQLite Dialect = sqliteDialect{}
+
+// codeError は結果コードを持つドライバのエラーです (modernc.org/sqliteのErrorが実装しています)
+type codeError interface {
+	error
+	Code() int
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) Rebind(query string) string { return questionRebind(query) }
+
+// RequestComment はスロークエリログなどクエリを外部から観測する仕組みがないため、コメントを付与しません
+func (sqliteDialect) RequestComment() bool { return false }
+
+func (sqliteDialect) Upsert(table string, columns, conflictColumns, updateColumns []string) string {
+	return onConflictUpsert(table, columns, conflictColumns, updateColumns)
+}
+
+// ForUpdate はSQLiteに行ロックがないため空文字列を返します
+// トランザクションは開始時にデータベース全体の書き込みロックを取得するため (_txlock=immediate)、更新は直列化されます
+func (sqliteDialect) ForUpdate() string { return "" }
+
+func (sqliteDialect) Limit() string { return "LIMIT ?" }
+
+func (sqliteDialect) LimitOffset() string { return "LIMIT ? OFFSET ?" }
+
+func (sqliteDialect) BindTime(t time.Time) any { return t.UTC().Format(sqliteTimeFormat) }
+
+// DuplicateKey は制約違反のメッセージからカラム名を取り出します
+// 例: UNIQUE constraint failed: users.email
+func (sqliteDialect) DuplicateKey(err error) (string, bool) {
+	var sqliteErr codeError
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code()&0xff != sqliteConstraint {
+		return "", false
+	}
+	_, columns, found := strings.Cut(sqliteErr.Error(), "UNIQUE constraint failed: ")
+	if !found {
+		return "", false
+	}
+	// 複合キーの場合は "users.a, users.b" となるため先頭のみ使う
+	column, _, _ := strings.Cut(columns, ",")
+	column, _, _ = strings.Cut(column, " (")
+	if _, name, ok := strings.Cut(column, "."); ok {
+		column = name
+	}
+	return strings.TrimSpace(column), true
+}
+
+func (sqliteDialect) IsRetryable(err error) bool {
+	var sqliteErr codeError
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code() & 0xff
+	return code == sqliteBusy || code == sqliteLocked
+}
+
+// Classify は結果コードからエラーを分類します
+// SQLiteはネットワーク接続を持たないため、ファイルを開けない・読み書きできない場合を接続エラーとします
+func (sqliteDialect) Classify(err error) error {
+	var sqliteErr codeError
+	if !errors.As(err, &sqliteErr) {
+		return classifyCommon(err)
+	}
+	switch sqliteErr.Code() & 0xff {
+	case sqliteCantOpen, sqliteIOErr:
+		return domainerror.ErrConnection
+	case sqliteBusy, sqliteLocked:
+		return domainerror.ErrTransaction
+	case sqliteError, sqliteTooBig, sqliteConstraint, sqliteMismatch, sqliteRange:
+		return domainerror.ErrQuery
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,250 @@
+package sqldb_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+func TestDialect_Rebind(t *testing.T) {
+	query := "SELECT * FROM users WHERE name = ? AND note = '?' AND id > ? LIMIT ? OFFSET ?"
+
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		expected string
+	}{
+		{
+			name:     "MySQLは?のまま",
+			dialect:  sqldb.MySQL,
+			expected: query,
+		},
+		{
+			name:     "SQLiteは?のまま",
+			dialect:  sqldb.SQLite,
+			expected: query,
+		},
+		{
+			name:     "PostgreSQLは$nに変換し、文字列リテラル内は変換しない",
+			dialect:  sqldb.PostgreSQL,
+			expected: "SELECT * FROM users WHERE name = $1 AND note = '?' AND id > $2 LIMIT $3 OFFSET $4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dialect.Rebind(query))
+		})
+	}
+}
+
+func TestDialect_Upsert(t *testing.T) {
+	columns := []string{"id", "name", "email"}
+
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		expected string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  sqldb.MySQL,
+			expected: "INSERT INTO users (id, name, email) VALUES (?, ?, ?) AS new ON DUPLICATE KEY UPDATE name = new.name, email = new.email",
+		},
+		{
+			name:     "PostgreSQL",
+			dialect:  sqldb.PostgreSQL,
+			expected: "INSERT INTO users (id, name, email) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name, email = excluded.email",
+		},
+		{
+			name:     "SQLite",
+			dialect:  sqldb.SQLite,
+			expected: "INSERT INTO users (id, name, email) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name, email = excluded.email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dialect.Upsert("users", columns, []string{"id"}, []string{"name", "email"}))
+		})
+	}
+}
+
+func TestDialect_Limit(t *testing.T) {
+	tests := []struct {
+		name                string
+		dialect             sqldb.Dialect
+		expectedLimit       string
+		expectedLimitOffset string
+	}{
+		{name: "MySQL", dialect: sqldb.MySQL, expectedLimit: "LIMIT ?", expectedLimitOffset: "LIMIT ? OFFSET ?"},
+		{name: "PostgreSQL", dialect: sqldb.PostgreSQL, expectedLimit: "LIMIT $1", expectedLimitOffset: "LIMIT $1 OFFSET $2"},
+		{name: "SQLite", dialect: sqldb.SQLite, expectedLimit: "LIMIT ?", expectedLimitOffset: "LIMIT ? OFFSET ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 句はプレースホルダを ? で返し、Rebindで方言の形式に変換する
+			assert.Equal(t, tt.expectedLimit, tt.dialect.Rebind(tt.dialect.Limit()))
+			assert.Equal(t, tt.expectedLimitOffset, tt.dialect.Rebind(tt.dialect.LimitOffset()))
+		})
+	}
+}
+
+func TestDialect_DuplicateKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialect     sqldb.Dialect
+		err         error
+		expectedKey string
+		expectedOK  bool
+	}{
+		{
+			name:        "MySQL 8.0の形式",
+			dialect:     sqldb.MySQL,
+			err:         &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@example.com' for key 'users.email'"},
+			expectedKey: "email",
+			expectedOK:  true,
+		},
+		{
+			name:        "MySQL 5.7の形式",
+			dialect:     sqldb.MySQL,
+			err:         fmt.Errorf("wrap: %w", &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'u1' for key 'PRIMARY'"}),
+			expectedKey: "PRIMARY",
+			expectedOK:  true,
+		},
+		{
+			name:       "MySQLの重複以外のエラー",
+			dialect:    sqldb.MySQL,
+			err:        &gomysql.MySQLError{Number: 1213, Message: "Deadlock found"},
+			expectedOK: false,
+		},
+		{
+			name:        "PostgreSQLの一意制約違反 (メッセージは翻訳されていても制約名を返す)",
+			dialect:     sqldb.PostgreSQL,
+			err:         fmt.Errorf("wrap: %w", &pgconn.PgError{Code: "23505", Message: `重複したキー値は一意性制約"users_email_key"違反となります`, ConstraintName: "users_email_key"}),
+			expectedKey: "users_email_key",
+			expectedOK:  true,
+		},
+		{
+			name:       "PostgreSQLの重複以外のエラー",
+			dialect:    sqldb.PostgreSQL,
+			err:        &pgconn.PgError{Code: "40001"},
+			expectedOK: false,
+		},
+		{
+			name:       "ドライバ以外のエラー",
+			dialect:    sqldb.SQLite,
+			err:        sql.ErrNoRows,
+			expectedOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := tt.dialect.DuplicateKey(tt.err)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+}
+
+func TestSQLite_DuplicateKey(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(sqliteSchema)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO users (id, name, email) VALUES ('u1', '田中', 'a@example.com')")
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO users (id, name, email) VALUES ('u2', '山田', 'a@example.com')")
+	key, ok := sqldb.SQLite.DuplicateKey(err)
+	assert.True(t, ok)
+	assert.Equal(t, "email", key)
+
+	_, err = db.Exec("INSERT INTO users (id, name, email) VALUES ('u1', '山田', 'b@example.com')")
+	key, ok = sqldb.SQLite.DuplicateKey(err)
+	assert.True(t, ok)
+	assert.Equal(t, "id", key)
+}
+
+func TestDialect_IsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		err      error
+		expected bool
+	}{
+		{name: "MySQLのデッドロック", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1213}, expected: true},
+		{name: "MySQLのロック待ちタイムアウト", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1205}, expected: true},
+		{name: "MySQLの重複", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1062}, expected: false},
+		{name: "PostgreSQLのシリアライゼーション失敗", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "40001"}, expected: true},
+		{name: "PostgreSQLのデッドロック", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "40P01"}, expected: true},
+		{name: "ドライバ以外のエラー", dialect: sqldb.SQLite, err: errors.New("error"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dialect.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestDialect_Classify(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		err      error
+		expected error
+	}{
+		{name: "MySQL: 接続拒否", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 2003, Message: "Can't connect to MySQL server"}, expected: domainerror.ErrConnection},
+		{name: "MySQL: サーバー切断", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 2006, Message: "MySQL server has gone away"}, expected: domainerror.ErrConnection},
+		{name: "MySQL: 不正な接続", dialect: sqldb.MySQL, err: gomysql.ErrInvalidConn, expected: domainerror.ErrConnection},
+		{name: "MySQL: driver.ErrBadConn", dialect: sqldb.MySQL, err: fmt.Errorf("wrap: %w", driver.ErrBadConn), expected: domainerror.ErrConnection},
+		{name: "MySQL: ネットワークエラー", dialect: sqldb.MySQL, err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, expected: domainerror.ErrConnection},
+		{name: "MySQL: デッドロック", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1213}, expected: domainerror.ErrTransaction},
+		{name: "MySQL: ロック待ちタイムアウト", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1205}, expected: domainerror.ErrTransaction},
+		{name: "MySQL: 終了済みトランザクション", dialect: sqldb.MySQL, err: sql.ErrTxDone, expected: domainerror.ErrTransaction},
+		{name: "MySQL: 構文エラー", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1064}, expected: domainerror.ErrQuery},
+		{name: "MySQL: データが長すぎる", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1406}, expected: domainerror.ErrQuery},
+		{name: "MySQL: 外部キー制約違反", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 1452}, expected: domainerror.ErrQuery},
+		{name: "MySQL: 未知のエラー番号", dialect: sqldb.MySQL, err: &gomysql.MySQLError{Number: 9999}, expected: nil},
+		{name: "MySQL: ドライバ以外のエラー", dialect: sqldb.MySQL, err: errors.New("error"), expected: nil},
+		{name: "PostgreSQL: 接続例外", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "08006"}, expected: domainerror.ErrConnection},
+		{name: "PostgreSQL: シリアライゼーション失敗", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "40001"}, expected: domainerror.ErrTransaction},
+		{name: "PostgreSQL: 構文エラー", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "42601"}, expected: domainerror.ErrQuery},
+		{name: "PostgreSQL: データが長すぎる", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "22001"}, expected: domainerror.ErrQuery},
+		{name: "PostgreSQL: 外部キー制約違反", dialect: sqldb.PostgreSQL, err: &pgconn.PgError{Code: "23503"}, expected: domainerror.ErrQuery},
+		{name: "SQLite: driver.ErrBadConn", dialect: sqldb.SQLite, err: driver.ErrBadConn, expected: domainerror.ErrConnection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dialect.Classify(tt.err))
+		})
+	}
+}
+
+func TestSQLite_Classify(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("SELEC 1")
+	assert.Equal(t, domainerror.ErrQuery, sqldb.SQLite.Classify(err))
+}
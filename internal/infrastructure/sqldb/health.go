@@ -0,0 +1,31 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+)
+
+// HealthChecker はデータベースへの疎通とコネクションプールの状態を確認します
+// プールの統計情報はdatabase/sqlが管理するため、方言によらず同じ項目を返します
+type HealthChecker struct {
+	db *sql.DB
+}
+
+// NewHealthChecker は新しいHealthCheckerを作成します
+func NewHealthChecker(db *sql.DB) *HealthChecker {
+	return &HealthChecker{db: db}
+}
+
+// Check はPingで疎通を確認し、プールの統計情報を返します
+func (h *HealthChecker) Check(ctx context.Context) (map[string]any, error) {
+	stats := h.db.Stats()
+	details := map[string]any{
+		"max_open_connections": stats.MaxOpenConnections,
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"wait_count":           stats.WaitCount,
+		"wait_duration":        stats.WaitDuration.String(),
+	}
+	return details, h.db.PingContext(ctx)
+}
@@ -0,0 +1,46 @@
+package sqldb_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+func TestHealthChecker_Check(t *testing.T) {
+	errPing := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		pingErr     error
+		expectedErr error
+	}{
+		{name: "成功"},
+		{name: "失敗: Pingのエラーを返す", pingErr: errPing, expectedErr: errPing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			defer db.Close()
+			m.ExpectPing().WillReturnError(tt.pingErr)
+
+			details, err := sqldb.NewHealthChecker(db).Check(context.Background())
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			// 失敗してもプールの状態は返し、すべての方言で同じ項目を含める
+			assert.ElementsMatch(t, []string{
+				"max_open_connections", "open_connections", "in_use", "idle", "wait_count", "wait_duration",
+			}, slices.Collect(maps.Keys(details)))
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
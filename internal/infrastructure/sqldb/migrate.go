@@ -0,0 +1,70 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+// Migrate は未適用のマイグレーションをすべて適用し、適用したものを返します
+// DDLをトランザクション内で実行できるDB (SQLite, PostgreSQL) 向けで、マイグレーション1件ごとにトランザクションで適用します
+// lockStmtが空でない場合、各トランザクションの最初に実行して他のプロセスと排他します
+// MySQLはDDLが暗黙的にコミットされるため、mysql/migrationのMigratorを使います
+func Migrate(ctx context.Context, db *sql.DB, dialect Dialect, migrations []migration.Migration, lockStmt string) ([]migration.Migration, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`)
+	if err != nil {
+		return nil, fmt.Errorf("schema_migrationsテーブルの作成に失敗しました: %w", err)
+	}
+
+	var applied []migration.Migration
+	for _, mig := range migrations {
+		ok, err := applyMigration(ctx, db, dialect, mig, lockStmt)
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied = append(applied, mig)
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration は未適用の場合のみマイグレーションを適用し、適用したかを返します
+func applyMigration(ctx context.Context, db *sql.DB, dialect Dialect, mig migration.Migration, lockStmt string) (bool, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback()
+
+	if lockStmt != "" {
+		if _, err := tx.ExecContext(ctx, lockStmt); err != nil {
+			return false, fmt.Errorf("ロックの取得に失敗しました: %w", err)
+		}
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, dialect.Rebind("SELECT COUNT(*) FROM schema_migrations WHERE version = ?"), mig.Version).Scan(&count); err != nil {
+		return false, fmt.Errorf("適用済みバージョンの取得に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+		return false, fmt.Errorf("マイグレーション %d_%s の実行に失敗しました: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, dialect.Rebind("INSERT INTO schema_migrations (version, name) VALUES (?, ?)"), mig.Version, mig.Name); err != nil {
+		return false, fmt.Errorf("schema_migrationsの更新に失敗しました: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package migration_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name: "成功: バージョン順に並ぶ",
+			files: fstest.MapFS{
+				"m/0002_add_index.up.sql":      {Data: []byte("CREATE INDEX a ON t (c);")},
+				"m/0002_add_index.down.sql":    {Data: []byte("DROP INDEX a ON t;")},
+				"m/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+				"m/0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "失敗: downファイルがない",
+			files: fstest.MapFS{
+				"m/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "失敗: ファイル名が不正",
+			files: fstest.MapFS{
+				"m/create_table.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := migration.Load(tt.files, "m")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			versions := make([]int64, 0, len(migrations))
+			for _, mig := range migrations {
+				versions = append(versions, mig.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// fieldIndexes は構造体の型ごとの「列名 → フィールド位置」の対応のキャッシュです
+var fieldIndexes sync.Map // map[reflect.Type]map[string]int
+
+// ScanAll はrowsのすべての行をTのスライスに読み込みます
+// 列はTのフィールドの `db` タグと名前で対応付け、対応するフィールドがない列はエラーにします
+// rowsはエラーの有無にかかわらず必ず閉じ、反復中のエラー (rows.Err) も返します
+func ScanAll[T any](rows *sql.Rows) (result []T, err error) {
+	defer func() {
+		if closeErr := rows.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := columnIndexes(reflect.TypeFor[T](), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	result = []T{}
+	dest := make([]any, len(columns))
+	for rows.Next() {
+		var v T
+		rv := reflect.ValueOf(&v).Elem()
+		for i, index := range indexes {
+			dest[i] = rv.Field(index).Addr().Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScanOne はrowsの最初の行をTに読み込みます
+// 行がない場合はsql.ErrNoRowsを返します。rowsの扱いはScanAllと同じです
+func ScanOne[T any](rows *sql.Rows) (T, error) {
+	var zero T
+	result, err := ScanAll[T](rows)
+	if err != nil {
+		return zero, err
+	}
+	if len(result) == 0 {
+		return zero, sql.ErrNoRows
+	}
+	return result[0], nil
+}
+
+// columnIndexes は各列に対応するフィールドの位置を返します
+func columnIndexes(t reflect.Type, columns []string) ([]int, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("読み込み先は構造体である必要があります: %s", t)
+	}
+
+	byName, ok := fieldIndexes.Load(t)
+	if !ok {
+		m := map[string]int{}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name := f.Tag.Get("db")
+			if !f.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			m[name] = i
+		}
+		byName, _ = fieldIndexes.LoadOrStore(t, m)
+	}
+
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		index, ok := byName.(map[string]int)[column]
+		if !ok {
+			return nil, fmt.Errorf("列 %s に対応するフィールドが %s にありません", column, t)
+		}
+		indexes[i] = index
+	}
+	return indexes, nil
+}
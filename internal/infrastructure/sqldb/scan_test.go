@@ -0,0 +1,150 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+type scanRow struct {
+	ID      string `db:"id"`
+	Name    string `db:"name"`
+	Ignored string
+}
+
+func TestScanAll(t *testing.T) {
+	errIteration := errors.New("反復中のエラー")
+
+	tests := []struct {
+		name        string
+		rows        func() *sqlmock.Rows
+		expected    []scanRow
+		expectedErr string
+	}{
+		{
+			name: "成功: 列名でフィールドに対応付ける",
+			rows: func() *sqlmock.Rows {
+				// 列の順序はフィールドの順序と異なってもよい
+				return sqlmock.NewRows([]string{"name", "id"}).AddRow("田中", "u1").AddRow("山田", "u2")
+			},
+			expected: []scanRow{{ID: "u1", Name: "田中"}, {ID: "u2", Name: "山田"}},
+		},
+		{
+			name: "成功: 行がない場合は空のスライス",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "name"})
+			},
+			expected: []scanRow{},
+		},
+		{
+			name: "失敗: 対応するフィールドがない列",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "email"}).AddRow("u1", "a@example.com")
+			},
+			expectedErr: "列 email に対応するフィールド",
+		},
+		{
+			name: "失敗: 反復中のエラーを返す",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "name"}).AddRow("u1", "田中").AddRow("u2", "山田").RowError(1, errIteration)
+			},
+			expectedErr: errIteration.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			m.ExpectQuery("SELECT").WillReturnRows(tt.rows()).RowsWillBeClosed()
+
+			rows, err := db.Query("SELECT")
+			require.NoError(t, err)
+			result, err := sqldb.ScanAll[scanRow](rows)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			// 成功・失敗にかかわらずrowsが閉じられている
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestScanOne_NoRows(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	m.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"})).RowsWillBeClosed()
+
+	rows, err := db.Query("SELECT")
+	require.NoError(t, err)
+	_, err = sqldb.ScanOne[scanRow](rows)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestUserRepository_NoConnectionLeak(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	// 接続が1つしかないため、リークすると以降のクエリはブロックする
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(sqliteSchema)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	repo := sqldb.NewUserRepository(db, sqldb.SQLite, 0)
+	for i := range 5 {
+		u := user.NewUser(fmt.Sprintf("u%d", i), "名前", user.ReconstructEmail(fmt.Sprintf("u%d@example.com", i)))
+		require.NoError(t, repo.CreateUser(ctx, u))
+	}
+
+	// 次ページがある (取得した行を読み残す) 場合
+	query := user.NewQuery()
+	query.Limit = 2
+	page, err := repo.GetUsers(ctx, query)
+	require.NoError(t, err)
+	require.NotNil(t, page.NextCursor)
+	assert.Zero(t, db.Stats().InUse)
+
+	_, err = repo.GetUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Zero(t, db.Stats().InUse)
+
+	_, err = repo.GetUserByID(ctx, "missing")
+	require.Error(t, err)
+	assert.Zero(t, db.Stats().InUse)
+}
+
+func TestUserRepository_RowsErr(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at"}).
+		AddRow("u1", "田中", "a@example.com", time.Time{}).
+		RowError(0, errors.New("接続が切断されました"))
+	m.ExpectQuery("SELECT id, name, email, created_at FROM users").WillReturnRows(rows).RowsWillBeClosed()
+
+	repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+	_, err = repo.GetUsers(context.Background(), user.NewQuery())
+
+	assert.ErrorContains(t, err, "接続が切断されました")
+	assert.NoError(t, m.ExpectationsWereMet())
+	assert.Zero(t, db.Stats().InUse)
+}
@@ -0,0 +1,69 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+
+// tracingExecutor はクエリの実行ごとにクライアントスパンを記録します
+// ステートメントにはプレースホルダのみが含まれ、引数の値は記録しません
+type tracingExecutor struct {
+	exec   executor
+	system string
+}
+
+// queryAll はクエリを実行し、すべての行をTのスライスに読み込みます (読み込みはScanAllと同じです)
+// 行はQueryContextから戻った後もサーバーから読み込まれるため、スパンは行を読み終えるまで続けます
+func queryAll[T any](ctx context.Context, e tracingExecutor, query string, args ...any) ([]T, error) {
+	ctx, span := e.start(ctx, "query", query)
+	defer span.End()
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	result, err := ScanAll[T](rows)
+	recordError(span, err)
+	return result, err
+}
+
+func (e tracingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := e.start(ctx, "exec", query)
+	defer span.End()
+	result, err := e.exec.ExecContext(ctx, query, args...)
+	recordError(span, err)
+	return result, err
+}
+
+func (e tracingExecutor) start(ctx context.Context, operation, query string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "sql."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(e.system),
+			semconv.DBQueryText(query),
+			attribute.Bool("db.in_transaction", isInTx(ctx)),
+		),
+	)
+}
+
+// isInTx はコンテキストにトランザクションが格納されているかを返します
+func isInTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return ok
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,106 @@
+package sqldb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+func TestUserRepository_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m.ExpectBegin()
+	m.ExpectQuery("SELECT id, name, email FROM users").WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow("u1", "田中太郎", "tanaka@example.com"))
+	m.ExpectExec("DELETE FROM users").WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectRollback()
+
+	repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+	uow := sqldb.NewTxManager(db, sqldb.MySQL)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := repo.GetUserByID(ctx, "u1"); err != nil {
+			return err
+		}
+		return repo.DeleteUser(ctx, "u1")
+	})
+	parent.End()
+	assert.ErrorIs(t, err, domainerror.ErrNotFound)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	require.Contains(t, spans, "sql.transaction")
+	require.Contains(t, spans, "sql.query")
+	require.Contains(t, spans, "sql.exec")
+
+	// クエリのスパンはトランザクションのスパンの子になる
+	txSpan := spans["sql.transaction"]
+	assert.Equal(t, parent.SpanContext().SpanID(), txSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, txSpan.Status().Code)
+
+	query := spans["sql.query"]
+	assert.Equal(t, txSpan.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind())
+	attrs := attribute.NewSet(query.Attributes()...)
+	system, _ := attrs.Value("db.system")
+	assert.Equal(t, "mysql", system.AsString())
+	statement, _ := attrs.Value("db.query.text")
+	assert.Equal(t, "SELECT id, name, email FROM users WHERE id = ?", statement.AsString(), "引数の値は記録しない")
+	inTx, _ := attrs.Value("db.in_transaction")
+	assert.True(t, inTx.AsBool())
+}
+
+func TestUserRepository_Tracing_RowError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// 行の読み込み中に接続が切れた場合
+	m.ExpectQuery("SELECT id, name, email, created_at FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "created_at"}).
+			AddRow("u1", "田中太郎", "tanaka@example.com", nil).
+			RowError(0, errors.New("connection reset by peer")))
+
+	repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+	_, err = repo.GetUsers(context.Background(), user.NewQuery())
+	require.Error(t, err)
+
+	// スパンは行の読み込みを含むため、読み込み中のエラーも記録される
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "sql.query", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection reset by peer", spans[0].Status().Description)
+}
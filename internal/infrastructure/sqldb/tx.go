@@ -0,0 +1,126 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// トランザクションのリトライ設定
+const (
+	txMaxAttempts    = 3
+	txRetryBaseDelay = 20 * time.Millisecond
+)
+
+// txKey はコンテキストにトランザクションを格納するためのキーです
+type txKey struct{}
+
+// executor は*sql.DBと*sql.Txに共通するクエリ実行のインターフェースです
+type executor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// conn はコンテキストにトランザクションがあればそれを、なければdbを返します
+// リポジトリはこれを通してクエリを発行することで、TxManager.Doのトランザクションに参加します
+// 方言がRequestCommentを返す場合は、発行するクエリにコンテキストのリクエストIDをコメントとして付与します
+// 実行ごとにスパンを記録し、SELECTはqueryAllで実行します
+func conn(ctx context.Context, db *sql.DB, dialect Dialect) tracingExecutor {
+	var exec executor = db
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		exec = tx
+	}
+	if dialect.RequestComment() {
+		exec = commentingExecutor{exec}
+	}
+	return tracingExecutor{exec: exec, system: dialect.Name()}
+}
+
+// TxManager は*sql.Txを使ったusecase.UnitOfWorkの実装です
+type TxManager struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewTxManager は新しいTxManagerを作成します
+func NewTxManager(db *sql.DB, dialect Dialect) *TxManager {
+	return &TxManager{db: db, dialect: dialect}
+}
+
+// Do はfnをトランザクション内で実行します
+// 既にトランザクション内で呼ばれた場合は外側のトランザクションに参加し、
+// コミット・ロールバックとリトライは最も外側のDoが行います
+// デッドロックなど方言が再実行可能と判定したエラーの場合はfn全体を再実行します
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= txMaxAttempts; attempt++ {
+		err = m.run(ctx, fn)
+		if err == nil || !m.dialect.IsRetryable(err) || attempt == txMaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return wrapContextError(ctx, ctx.Err())
+		case <-time.After(txRetryBaseDelay * time.Duration(attempt)):
+		}
+	}
+	return err
+}
+
+// run はトランザクションを1回実行します
+// 再実行を区別できるよう、試行ごとにスパンを記録します
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "sql.transaction",
+		trace.WithAttributes(semconv.DBSystemKey.String(m.dialect.Name())))
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return m.wrapError(ctx, "begin", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return fmt.Errorf("%w: %w (rollback: %v)", domainerror.ErrTransaction, err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return m.wrapError(ctx, "commit", err)
+	}
+	return nil
+}
+
+// wrapError はトランザクション制御のエラーをDatabaseErrorに変換します
+// 方言で分類できないエラーはErrTransactionとして扱います
+func (m *TxManager) wrapError(ctx context.Context, operation string, err error) error {
+	kind := m.dialect.Classify(err)
+	if kind == nil {
+		kind = domainerror.ErrTransaction
+	}
+	return wrapContextError(ctx, domainerror.NewDatabaseError(kind, operation, "", err))
+}
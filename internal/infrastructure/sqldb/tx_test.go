@@ -0,0 +1,141 @@
+package sqldb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+func TestTxManager_Do(t *testing.T) {
+	errApp := errors.New("アプリケーションエラー")
+	deadlock := &gomysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(m sqlmock.Sqlmock)
+		fn          func(repo *sqldb.UserRepository, uow *sqldb.TxManager) func(ctx context.Context) error
+		expectedErr error
+	}{
+		{
+			name: "成功: リポジトリがトランザクションに参加してコミット",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM users").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM users").WithArgs("2").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(repo *sqldb.UserRepository, _ *sqldb.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.DeleteUser(ctx, "1"); err != nil {
+						return err
+					}
+					return repo.DeleteUser(ctx, "2")
+				}
+			},
+		},
+		{
+			name: "成功: ネストしたDoは外側のトランザクションに参加",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM users").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(repo *sqldb.UserRepository, uow *sqldb.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return uow.Do(ctx, func(ctx context.Context) error {
+						return repo.DeleteUser(ctx, "1")
+					})
+				}
+			},
+		},
+		{
+			name: "失敗: エラー時はロールバック",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM users").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectRollback()
+			},
+			fn: func(repo *sqldb.UserRepository, _ *sqldb.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.DeleteUser(ctx, "1"); err != nil {
+						return err
+					}
+					return errApp
+				}
+			},
+			expectedErr: errApp,
+		},
+		{
+			name: "成功: デッドロック時は再実行",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM users").WithArgs("1").WillReturnError(deadlock)
+				m.ExpectRollback()
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM users").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(repo *sqldb.UserRepository, _ *sqldb.TxManager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.DeleteUser(ctx, "1")
+				}
+			},
+		},
+		{
+			name: "失敗: コミットに失敗",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit().WillReturnError(errors.New("commit failed"))
+			},
+			fn: func(_ *sqldb.UserRepository, _ *sqldb.TxManager) func(ctx context.Context) error {
+				return func(_ context.Context) error { return nil }
+			},
+			expectedErr: domainerror.ErrTransaction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(m)
+
+			repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+			uow := sqldb.NewTxManager(db, sqldb.MySQL)
+
+			err = uow.Do(context.Background(), tt.fn(repo, uow))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTxManager_Do_RollbackOnPanic(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m.ExpectBegin()
+	m.ExpectRollback()
+
+	uow := sqldb.NewTxManager(db, sqldb.MySQL)
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = uow.Do(context.Background(), func(_ context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, m.ExpectationsWereMet())
+}
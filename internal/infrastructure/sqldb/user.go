@@ -0,0 +1,244 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+// emailUniqueKeys はメールアドレスの一意制約の名前 (Dialect.DuplicateKeyが返す値) です
+// マイグレーションで一意制約を追加・変更した場合はここも更新します
+var emailUniqueKeys = map[string]bool{
+	"email":           true, // MySQL (インデックス名)、SQLite (カラム名)
+	"users_email_key": true, // PostgreSQL (制約名)
+}
+
+type UserRepository struct {
+	db           *sql.DB
+	dialect      Dialect
+	queryTimeout time.Duration
+}
+
+// userRow はusersテーブルの1行です
+type userRow struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r userRow) toUser() *user.User {
+	return user.NewUser(r.ID, r.Name, user.ReconstructEmail(r.Email))
+}
+
+// NewUserRepository はUserRepositoryを作成します
+// queryTimeoutが0より大きい場合、各クエリにその期限を設定します
+func NewUserRepository(db *sql.DB, dialect Dialect, queryTimeout time.Duration) *UserRepository {
+	return &UserRepository{db: db, dialect: dialect, queryTimeout: queryTimeout}
+}
+
+// withTimeout はリクエストのコンテキストにクエリ単位の期限を設定します
+func (r *UserRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+func (r *UserRepository) GetUsers(ctx context.Context, query user.Query) (*user.Page, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	stmt, args, err := r.buildUsersQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := queryAll[userRow](ctx, conn(ctx, r.db, r.dialect), stmt, args...)
+	if err != nil {
+		return nil, r.wrapError(ctx, "select", err)
+	}
+
+	page := &user.Page{Users: []*user.User{}}
+	// 次ページの有無を判定するため1件多く取得している
+	if len(records) > query.Limit {
+		records = records[:query.Limit]
+		last := records[len(records)-1]
+		page.NextCursor = user.NewCursor(query.Sort, last.toUser(), last.CreatedAt)
+	}
+	for _, rec := range records {
+		page.Users = append(page.Users, rec.toUser())
+	}
+
+	return page, nil
+}
+
+// buildUsersQuery は検索仕様をキーセットページネーションのSELECT文に変換します
+func (r *UserRepository) buildUsersQuery(query user.Query) (string, []any, error) {
+	column := "name"
+	if query.Sort.Field == user.SortByCreatedAt {
+		column = "created_at"
+	}
+	cmp, order := ">", "ASC"
+	if query.Sort.Desc {
+		cmp, order = "<", "DESC"
+	}
+
+	var conds []string
+	var args []any
+
+	if query.NamePrefix != "" {
+		conds = append(conds, "name LIKE ? ESCAPE '!'")
+		args = append(args, escapeLike(query.NamePrefix)+"%")
+	}
+	if !query.CreatedFrom.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, r.dialect.BindTime(query.CreatedFrom))
+	}
+	if !query.CreatedTo.IsZero() {
+		conds = append(conds, "created_at < ?")
+		args = append(args, r.dialect.BindTime(query.CreatedTo))
+	}
+	if query.After != nil {
+		var key any = query.After.Key
+		if query.Sort.Field == user.SortByCreatedAt {
+			createdAt, err := query.After.CreatedAt()
+			if err != nil {
+				return "", nil, err
+			}
+			key = r.dialect.BindTime(createdAt)
+		}
+		conds = append(conds, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, cmp))
+		args = append(args, key, key, query.After.ID)
+	}
+
+	stmt := "SELECT id, name, email, created_at FROM users"
+	if len(conds) > 0 {
+		stmt += " WHERE " + strings.Join(conds, " AND ")
+	}
+	stmt += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s ", column, order) + r.dialect.Limit()
+	args = append(args, query.Limit+1)
+
+	return r.dialect.Rebind(stmt), args, nil
+}
+
+// escapeLike はLIKEパターンの特殊文字をエスケープします
+// バックスラッシュの扱いが方言ごとに異なるため、エスケープ文字には ! を使います
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*user.User, error) {
+	return r.getUserByID(ctx, "SELECT id, name, email FROM users WHERE id = ?", id)
+}
+
+// GetUserByIDForUpdate は行をロックしてユーザーを取得します
+// TxManager.Doのトランザクション内で呼び出した場合、ロックはトランザクションの終了まで保持されます
+func (r *UserRepository) GetUserByIDForUpdate(ctx context.Context, id string) (*user.User, error) {
+	return r.getUserByID(ctx, "SELECT id, name, email FROM users WHERE id = ?"+r.dialect.ForUpdate(), id)
+}
+
+func (r *UserRepository) getUserByID(ctx context.Context, query, id string) (*user.User, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	records, err := queryAll[userRow](ctx, conn(ctx, r.db, r.dialect), r.dialect.Rebind(query), id)
+	if err != nil {
+		return nil, r.wrapError(ctx, "select", err)
+	}
+	if len(records) == 0 {
+		return nil, domainerror.NewNotFoundError("User", id)
+	}
+	return records[0].toUser(), nil
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *user.User) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := conn(ctx, r.db, r.dialect).ExecContext(ctx, r.dialect.Rebind("INSERT INTO users (id, name, email) VALUES (?, ?, ?)"), user.ID, user.Name, user.Email.String())
+	if err != nil {
+		if dupErr := r.duplicateError(err, user); dupErr != nil {
+			return dupErr
+		}
+		return r.wrapError(ctx, "insert", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, user *user.User) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	// SQLiteはON UPDATE句を持たないため、updated_atは明示的に更新する
+	result, err := conn(ctx, r.db, r.dialect).ExecContext(ctx, r.dialect.Rebind("UPDATE users SET name = ?, email = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"), user.Name, user.Email.String(), user.ID)
+	if err != nil {
+		if dupErr := r.duplicateError(err, user); dupErr != nil {
+			return dupErr
+		}
+		return r.wrapError(ctx, "update", err)
+	}
+	return r.checkAffected(ctx, "update", result, user.ID)
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := conn(ctx, r.db, r.dialect).ExecContext(ctx, r.dialect.Rebind("DELETE FROM users WHERE id = ?"), id)
+	if err != nil {
+		return r.wrapError(ctx, "delete", err)
+	}
+	return r.checkAffected(ctx, "delete", result, id)
+}
+
+// checkAffected は更新対象の行が存在しなかった場合にNotFoundErrorを返します
+// MySQLでは値が変わらないUPDATEでも0件にならないよう、DSNでclientFoundRowsを有効にしています
+func (r *UserRepository) checkAffected(ctx context.Context, operation string, result sql.Result, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return r.wrapError(ctx, operation, err)
+	}
+	if affected == 0 {
+		return domainerror.NewNotFoundError("User", id)
+	}
+	return nil
+}
+
+// duplicateError は一意制約違反をどのキーで発生したかに応じてドメインエラーに変換します
+// 一意制約違反でない場合はnilを返します
+func (r *UserRepository) duplicateError(err error, u *user.User) error {
+	key, ok := r.dialect.DuplicateKey(err)
+	if !ok {
+		return nil
+	}
+	if emailUniqueKeys[key] {
+		return domainerror.NewDuplicateEmailError(u.Email.String())
+	}
+	return domainerror.NewDuplicateEntryError(u.ID, u.Name)
+}
+
+// wrapError はドライバのエラーを方言で分類し、DatabaseErrorに変換します
+func (r *UserRepository) wrapError(ctx context.Context, operation string, err error) error {
+	return wrapContextError(ctx, domainerror.NewDatabaseError(r.dialect.Classify(err), operation, "users", err))
+}
+
+// wrapContextError はコンテキストの期限切れ・キャンセルによる失敗をドメインエラーに変換します
+// ドライバはキャンセル時に driver.ErrBadConn 等を返すことがあるため、ctx.Err()も確認します
+func wrapContextError(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctxErr, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", domainerror.ErrTimeout, err)
+	case errors.Is(err, context.Canceled) || errors.Is(ctxErr, context.Canceled):
+		return fmt.Errorf("%w: %w", domainerror.ErrCanceled, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,266 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/user/usertest"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+const sqliteSchema = `CREATE TABLE users (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+func TestUserRepository_SQLite(t *testing.T) {
+	usertest.RunRepositoryContract(t, func(t *testing.T) user.Repository {
+		db, err := sql.Open("sqlite", ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		// インメモリDBは接続ごとに別のデータベースになるため接続を1つに限定する
+		db.SetMaxOpenConns(1)
+
+		_, err = db.Exec(sqliteSchema)
+		require.NoError(t, err)
+		return sqldb.NewUserRepository(db, sqldb.SQLite, 0)
+	})
+}
+
+func TestUserRepository_DatabaseError(t *testing.T) {
+	u := user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com"))
+
+	tests := []struct {
+		name              string
+		setupMock         func(m sqlmock.Sqlmock)
+		call              func(repo *sqldb.UserRepository) error
+		expectedKind      error
+		expectedOperation string
+	}{
+		{
+			name: "接続断はErrConnection",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT id, name, email FROM users").
+					WillReturnError(&gomysql.MySQLError{Number: 2013, Message: "Lost connection to MySQL server during query"})
+			},
+			call: func(repo *sqldb.UserRepository) error {
+				_, err := repo.GetUserByID(context.Background(), "u1")
+				return err
+			},
+			expectedKind:      domainerror.ErrConnection,
+			expectedOperation: "select",
+		},
+		{
+			name: "デッドロックはErrTransaction",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE users").WillReturnError(&gomysql.MySQLError{Number: 1213, Message: "Deadlock found"})
+			},
+			call: func(repo *sqldb.UserRepository) error {
+				return repo.UpdateUser(context.Background(), u)
+			},
+			expectedKind:      domainerror.ErrTransaction,
+			expectedOperation: "update",
+		},
+		{
+			name: "データ長超過はErrQuery",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO users").WillReturnError(&gomysql.MySQLError{Number: 1406, Message: "Data too long for column 'name'"})
+			},
+			call: func(repo *sqldb.UserRepository) error {
+				return repo.CreateUser(context.Background(), u)
+			},
+			expectedKind:      domainerror.ErrQuery,
+			expectedOperation: "insert",
+		},
+		{
+			name: "外部キー制約違反はErrQuery",
+			setupMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM users").WillReturnError(&gomysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"})
+			},
+			call: func(repo *sqldb.UserRepository) error {
+				return repo.DeleteUser(context.Background(), "u1")
+			},
+			expectedKind:      domainerror.ErrQuery,
+			expectedOperation: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(m)
+
+			err = tt.call(sqldb.NewUserRepository(db, sqldb.MySQL, 0))
+
+			var dbErr *domainerror.DatabaseError
+			require.ErrorAs(t, err, &dbErr)
+			assert.Equal(t, tt.expectedOperation, dbErr.Operation)
+			assert.Equal(t, "users", dbErr.Table)
+			assert.ErrorIs(t, err, tt.expectedKind)
+			assert.ErrorIs(t, err, domainerror.ErrDatabase)
+
+			// 元のドライバのエラーを取り出せる
+			var mysqlErr *gomysql.MySQLError
+			assert.ErrorAs(t, err, &mysqlErr)
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_DuplicateIsNotDatabaseError(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	m.ExpectExec("INSERT INTO users").
+		WillReturnError(&gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'u1' for key 'users.PRIMARY'"})
+
+	repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+	err = repo.CreateUser(context.Background(), user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com")))
+
+	assert.ErrorIs(t, err, domainerror.ErrDuplicated)
+	assert.NotErrorIs(t, err, domainerror.ErrDatabase)
+}
+
+func TestUserRepository_RequestIDComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "リクエストIDをコメントとして付与する",
+			dialect:  sqldb.MySQL,
+			ctx:      requestid.WithID(context.Background(), "req-1"),
+			expected: `^/\* request_id=req-1 \*/ DELETE FROM users WHERE id = \?$`,
+		},
+		{
+			name:     "リクエストIDがなければ付与しない",
+			dialect:  sqldb.MySQL,
+			ctx:      context.Background(),
+			expected: `^DELETE FROM users WHERE id = \?$`,
+		},
+		{
+			name:     "形式が不正なリクエストIDは付与しない",
+			dialect:  sqldb.MySQL,
+			ctx:      requestid.WithID(context.Background(), "x */ DROP TABLE users; /*"),
+			expected: `^DELETE FROM users WHERE id = \?$`,
+		},
+		{
+			name:     "PostgreSQLはステートメントキャッシュが効くよう付与しない",
+			dialect:  sqldb.PostgreSQL,
+			ctx:      requestid.WithID(context.Background(), "req-1"),
+			expected: `^DELETE FROM users WHERE id = \$1$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			m.ExpectExec(tt.expected).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+			repo := sqldb.NewUserRepository(db, tt.dialect, 0)
+			require.NoError(t, repo.DeleteUser(tt.ctx, "u1"))
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetUserByIDForUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  sqldb.Dialect
+		expected string
+	}{
+		{name: "MySQLは行をロックする", dialect: sqldb.MySQL, expected: `^SELECT id, name, email FROM users WHERE id = \? FOR UPDATE$`},
+		{name: "PostgreSQLは行をロックする", dialect: sqldb.PostgreSQL, expected: `^SELECT id, name, email FROM users WHERE id = \$1 FOR UPDATE$`},
+		{name: "SQLiteはロック句を付けない", dialect: sqldb.SQLite, expected: `^SELECT id, name, email FROM users WHERE id = \?$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			m.ExpectQuery(tt.expected).WithArgs("u1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow("u1", "田中太郎", "tanaka@example.com"))
+
+			repo := sqldb.NewUserRepository(db, tt.dialect, 0)
+			u, err := repo.GetUserByIDForUpdate(context.Background(), "u1")
+			require.NoError(t, err)
+			assert.Equal(t, "u1", u.ID)
+			assert.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetUsersQuery(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	// 次のページの有無を判定するため1件多く取得し、開始位置は指定しない
+	m.ExpectQuery(`^SELECT id, name, email, created_at FROM users ORDER BY created_at ASC, id ASC LIMIT \?$`).
+		WithArgs(user.DefaultLimit + 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "created_at"}))
+
+	repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+	_, err = repo.GetUsers(context.Background(), user.NewQuery())
+	require.NoError(t, err)
+	assert.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestUserRepository_DuplicateKey(t *testing.T) {
+	u := user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com"))
+
+	tests := []struct {
+		name        string
+		message     string
+		expectedErr any
+	}{
+		{
+			name:        "メールアドレスの一意制約違反はDuplicateEmailError",
+			message:     "Duplicate entry 'tanaka@example.com' for key 'users.email'",
+			expectedErr: &domainerror.DuplicateEmailError{},
+		},
+		{
+			name:        "主キーの重複はDuplicateEntryError",
+			message:     "Duplicate entry 'u1' for key 'users.PRIMARY'",
+			expectedErr: &domainerror.DuplicateEntryError{},
+		},
+		{
+			name:        "名前にemailを含む別の一意制約はDuplicateEntryError",
+			message:     "Duplicate entry 'x' for key 'users.backup_email_hash'",
+			expectedErr: &domainerror.DuplicateEntryError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			m.ExpectExec("INSERT INTO users").WillReturnError(&gomysql.MySQLError{Number: 1062, Message: tt.message})
+
+			repo := sqldb.NewUserRepository(db, sqldb.MySQL, 0)
+			err = repo.CreateUser(context.Background(), u)
+			assert.IsType(t, tt.expectedErr, err)
+		})
+	}
+}
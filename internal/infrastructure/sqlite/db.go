@@ -0,0 +1,62 @@
+// Package sqlite はSQLiteを使った永続化を提供します
+// 純Goのドライバを使うため、MySQLのコンテナを用意せずに単一のバイナリでアプリケーションを動かせます
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	// SQLiteドライバを初期化のために必要
+	_ "modernc.org/sqlite"
+)
+
+// MemoryPath はインメモリデータベースを使う場合のPathです
+const MemoryPath = ":memory:"
+
+// DBConfig はデータベース接続の設定を保持します
+type DBConfig struct {
+	// Path はデータベースファイルのパスです (MemoryPathの場合はインメモリ)
+	Path string
+	// QueryTimeout はクエリ1回あたりの実行期限です (0の場合はリクエストの期限のみに従います)
+	QueryTimeout time.Duration
+}
+
+// NewConnection は新しいデータベース接続を作成します
+func NewConnection(config DBConfig) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	// 読み取り後の書き込みでロックの昇格に失敗しないよう、開始時に書き込みロックを取得する
+	params.Set("_txlock", "immediate")
+	if config.Path != MemoryPath {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	db, err := sql.Open("sqlite", config.Path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("データベース接続エラー: %w", err)
+	}
+
+	// インメモリデータベースは接続ごとに別のデータベースになるため、接続を1つに限定する
+	// ファイルの場合も書き込みは直列化されるため、接続数は少なくてよい
+	if config.Path == MemoryPath {
+		db.SetMaxOpenConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+	} else {
+		db.SetMaxOpenConns(4)
+		db.SetMaxIdleConns(4)
+	}
+
+	// 接続テスト
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("データベース接続テストエラー: %w", err)
+	}
+
+	slog.Info("データベースに接続しました", "driver", "sqlite", "path", config.Path)
+	return db, nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb/migration"
+)
+
+// migrationFiles はSQLite用のマイグレーションファイルです
+// ファイル名の形式はmigrationパッケージと共通です
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate は未適用のマイグレーションをすべて適用し、適用したものを返します
+// 単一のプロセスから使う前提のため、起動時に呼び出します
+func Migrate(ctx context.Context, db *sql.DB) ([]migration.Migration, error) {
+	migrations, err := migration.Load(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	// トランザクションは書き込みロックを取得して開始するため、追加のロックは不要
+	return sqldb.Migrate(ctx, db, sqldb.SQLite, migrations, "")
+}
@@ -0,0 +1,20 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqldb"
+)
+
+// NewUserRepository はSQLite用のUserRepositoryを作成します
+// queryTimeoutが0より大きい場合、各クエリにその期限を設定します
+func NewUserRepository(db *sql.DB, queryTimeout time.Duration) *sqldb.UserRepository {
+	return sqldb.NewUserRepository(db, sqldb.SQLite, queryTimeout)
+}
+
+// NewTxManager はSQLite用のTxManagerを作成します
+// データベースがロックされている (SQLITE_BUSY, SQLITE_LOCKED) 場合はトランザクションを再実行します
+func NewTxManager(db *sql.DB) *sqldb.TxManager {
+	return sqldb.NewTxManager(db, sqldb.SQLite)
+}
@@ -0,0 +1,181 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/domain/user/usertest"
+	"github.com/nansystem/go-ddd/internal/infrastructure/sqlite"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+func TestUserRepository(t *testing.T) {
+	usertest.RunRepositoryContract(t, func(t *testing.T) user.Repository {
+		return sqlite.NewUserRepository(openDB(t, sqlite.MemoryPath), 0)
+	})
+}
+
+func TestUserRepository_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	usertest.RunRepositoryContract(t, func(t *testing.T) user.Repository {
+		db := openDB(t, path)
+		_, err := db.Exec("DELETE FROM users")
+		require.NoError(t, err)
+		return sqlite.NewUserRepository(db, 0)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	db := openDB(t, sqlite.MemoryPath)
+
+	// openDBで適用済みのため、再実行しても何も適用しない
+	applied, err := sqlite.Migrate(context.Background(), db)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestTxManager_Do(t *testing.T) {
+	errApp := errors.New("アプリケーションエラー")
+
+	tests := []struct {
+		name        string
+		fn          func(repo user.Repository) func(ctx context.Context) error
+		expectedErr error
+		expectedIDs []string
+	}{
+		{
+			name: "成功: コミットされる",
+			fn: func(repo user.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com"))
+				}
+			},
+			expectedIDs: []string{"u1", "u2"},
+		},
+		{
+			name: "失敗: ロールバックされる",
+			fn: func(repo user.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com")); err != nil {
+						return err
+					}
+					return errApp
+				}
+			},
+			expectedErr: errApp,
+			expectedIDs: []string{"u1"},
+		},
+		{
+			name: "失敗: 一意制約違反がドメインエラーとして返りロールバックされる",
+			fn: func(repo user.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.CreateUser(ctx, newUser(t, "u2", "u2@example.com")); err != nil {
+						return err
+					}
+					return repo.CreateUser(ctx, newUser(t, "u3", "u1@example.com"))
+				}
+			},
+			expectedErr: domainerror.ErrDuplicated,
+			expectedIDs: []string{"u1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := openDB(t, sqlite.MemoryPath)
+			repo := sqlite.NewUserRepository(db, 0)
+			require.NoError(t, repo.CreateUser(ctx, newUser(t, "u1", "u1@example.com")))
+
+			err := sqlite.NewTxManager(db).Do(ctx, tt.fn(repo))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			page, err := repo.GetUsers(ctx, user.NewQuery())
+			require.NoError(t, err)
+			ids := []string{}
+			for _, u := range page.Users {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestUserService_PatchUser_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	// 接続ごとに別のトランザクションになるようファイルのデータベースを使う
+	db := openDB(t, filepath.Join(t.TempDir(), "app.db"))
+	repo := sqlite.NewUserRepository(db, 0)
+	require.NoError(t, repo.CreateUser(ctx, newUser(t, "u1", "u1@example.com")))
+	service := usecase.NewUserService(repo, nil, sqlite.NewTxManager(db))
+
+	// 名前とメールアドレスを別々のリクエストで同時に更新しても、どちらの更新も失われない
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := range n {
+		name := fmt.Sprintf("名前%d", i)
+		email, err := user.NewEmail(fmt.Sprintf("u1+%d@example.com", i))
+		require.NoError(t, err)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := service.PatchUser(ctx, "u1", &usecase.UserPatch{Name: &name})
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := service.PatchUser(ctx, "u1", &usecase.UserPatch{Email: &email})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	got, err := repo.GetUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.NotEqual(t, "名前", got.Name)
+	assert.NotEqual(t, "u1@example.com", got.Email.String())
+}
+
+func openDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := sqlite.NewConnection(sqlite.DBConfig{Path: path})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = sqlite.Migrate(context.Background(), db)
+	require.NoError(t, err)
+	return db
+}
+
+func newUser(t *testing.T, id, email string) *user.User {
+	t.Helper()
+
+	e, err := user.NewEmail(email)
+	require.NoError(t, err)
+	return user.NewUser(id, "名前", e)
+}
@@ -0,0 +1,83 @@
+// Package lifecycle はアプリケーション終了時の停止処理を順序付けて実行します
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// Phase は停止処理を実行する段階です
+// 値の小さい段階から順に停止します
+type Phase int
+
+const (
+	// PhaseHTTP は新規リクエストの受付停止と処理中リクエストの完了待ちです
+	PhaseHTTP Phase = iota
+	// PhaseWorkers はバックグラウンド処理の停止です
+	PhaseWorkers
+	// PhaseDatabase はコネクションプールなどの外部リソースの解放です
+	PhaseDatabase
+	// PhaseTelemetry は未送信のトレースなど計測データの送信です
+	// 他の段階の停止処理で記録されたデータも送れるよう、最後に実行します
+	PhaseTelemetry
+)
+
+// StopFunc は停止処理です
+// ctxにはシャットダウン全体の期限が設定されています
+type StopFunc func(ctx context.Context) error
+
+type hook struct {
+	phase Phase
+	name  string
+	stop  StopFunc
+}
+
+// Lifecycle は停止処理を登録し、シャットダウン時に段階順に実行します
+type Lifecycle struct {
+	mu    sync.Mutex
+	hooks []hook
+	done  bool
+}
+
+// New は新しいLifecycleを作成します
+func New() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// OnStop は停止処理を登録します
+// 同じ段階の処理は登録順に実行されます
+func (l *Lifecycle) OnStop(phase Phase, name string, stop StopFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook{phase: phase, name: name, stop: stop})
+}
+
+// Shutdown は登録された停止処理を段階順に実行します
+// 途中の処理が失敗しても後続の処理は実行し、すべてのエラーをまとめて返します
+// 2回目以降の呼び出しは何もしません
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	if l.done {
+		l.mu.Unlock()
+		return nil
+	}
+	l.done = true
+	hooks := make([]hook, len(l.hooks))
+	copy(hooks, l.hooks)
+	l.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].phase < hooks[j].phase })
+
+	var errs []error
+	for _, h := range hooks {
+		slog.Info("停止しています", "hook", h.name)
+		if err := h.stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s の停止に失敗しました: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
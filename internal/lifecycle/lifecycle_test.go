@@ -0,0 +1,38 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nansystem/go-ddd/internal/lifecycle"
+)
+
+func TestLifecycle_Shutdown(t *testing.T) {
+	lc := lifecycle.New()
+
+	var order []string
+	record := func(name string, err error) lifecycle.StopFunc {
+		return func(_ context.Context) error {
+			order = append(order, name)
+			return err
+		}
+	}
+
+	// 登録順に関わらず HTTP → ワーカー → DB の順で停止すること
+	errWorker := errors.New("worker error")
+	lc.OnStop(lifecycle.PhaseDatabase, "db", record("db", nil))
+	lc.OnStop(lifecycle.PhaseWorkers, "worker1", record("worker1", errWorker))
+	lc.OnStop(lifecycle.PhaseHTTP, "http", record("http", nil))
+	lc.OnStop(lifecycle.PhaseWorkers, "worker2", record("worker2", nil))
+
+	err := lc.Shutdown(context.Background())
+	assert.ErrorIs(t, err, errWorker)
+	assert.Equal(t, []string{"http", "worker1", "worker2", "db"}, order)
+
+	// 2回目は何もしない
+	assert.NoError(t, lc.Shutdown(context.Background()))
+	assert.Len(t, order, 4)
+}
@@ -0,0 +1,97 @@
+// Package logging はlog/slogによるアプリケーション共通のロガーを提供します
+// リクエストごとのロガーはコンテキストに格納して受け渡します
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// 出力形式
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config はロガーの設定です
+type Config struct {
+	// Format は出力形式です (json, text)
+	Format string
+	// Level は出力する最低のログレベルです
+	Level slog.Level
+}
+
+// New は設定に従ってwに出力するロガーを作成します
+func New(w io.Writer, config Config) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: config.Level}
+	switch config.Format {
+	case FormatJSON, "":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("ログの出力形式が不正です: %q (json, text のいずれかを指定してください)", config.Format)
+	}
+}
+
+type loggerKey struct{}
+
+type attrsKey struct{}
+
+// attrBag はリクエストの処理中に追加された属性です
+type attrBag struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// WithLogger はロガーを格納したコンテキストを返します
+// 同時に、AddAttrsで属性を追加できるようにします
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	ctx = context.WithValue(ctx, loggerKey{}, logger)
+	return context.WithValue(ctx, attrsKey{}, &attrBag{})
+}
+
+// FromContext はコンテキストに格納されたロガーを返します
+// 格納されていない場合はslog.Default()を返します
+// AddAttrsで追加された属性も付与されます
+func FromContext(ctx context.Context) *slog.Logger {
+	logger, ok := ctx.Value(loggerKey{}).(*slog.Logger)
+	if !ok {
+		logger = slog.Default()
+	}
+	if attrs := Attrs(ctx); len(attrs) > 0 {
+		args := make([]any, len(attrs))
+		for i, a := range attrs {
+			args[i] = a
+		}
+		logger = logger.With(args...)
+	}
+	return logger
+}
+
+// AddAttrs はリクエストのログに含める属性を追加します
+// ハンドラーなど下位の層で判明した情報 (操作対象のユーザーIDなど) をアクセスログに含めるために使います
+// WithLoggerを通していないコンテキストでは何もしません
+func AddAttrs(ctx context.Context, attrs ...slog.Attr) {
+	bag, ok := ctx.Value(attrsKey{}).(*attrBag)
+	if !ok {
+		return
+	}
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+	bag.attrs = append(bag.attrs, attrs...)
+}
+
+// Attrs はAddAttrsで追加された属性を返します
+func Attrs(ctx context.Context) []slog.Attr {
+	bag, ok := ctx.Value(attrsKey{}).(*attrBag)
+	if !ok {
+		return nil
+	}
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+	return append([]slog.Attr(nil), bag.attrs...)
+}
@@ -0,0 +1,81 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/logging"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      logging.Config
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "JSON形式",
+			config:   logging.Config{Format: logging.FormatJSON, Level: slog.LevelInfo},
+			expected: `"msg":"info"`,
+		},
+		{
+			name:     "テキスト形式",
+			config:   logging.Config{Format: logging.FormatText, Level: slog.LevelInfo},
+			expected: "msg=info",
+		},
+		{
+			name:        "不正な形式",
+			config:      logging.Config{Format: "xml"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := logging.New(&buf, tt.config)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			logger.Debug("debug")
+			logger.Info("info")
+
+			assert.Contains(t, buf.String(), tt.expected)
+			assert.NotContains(t, buf.String(), "debug")
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.Config{Format: logging.FormatJSON})
+	require.NoError(t, err)
+
+	ctx := logging.WithLogger(context.Background(), logger.With("request_id", "req-1"))
+	logging.AddAttrs(ctx, slog.String("user_id", "u1"))
+	logging.FromContext(ctx).Info("hello")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "u1", record["user_id"])
+}
+
+func TestFromContext_Default(t *testing.T) {
+	ctx := context.Background()
+
+	// ロガーが格納されていない場合は既定のロガーを返し、属性の追加は無視する
+	logging.AddAttrs(ctx, slog.String("user_id", "u1"))
+	assert.Same(t, slog.Default(), logging.FromContext(ctx))
+	assert.Empty(t, logging.Attrs(ctx))
+}
@@ -0,0 +1,123 @@
+// Package metrics はPrometheus形式のメトリクスを提供します
+// 各層はMetricsのメソッドを通して記録し、/metricsで公開します
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "go_ddd"
+
+// Metrics はアプリケーションのメトリクスとそれを登録するレジストリです
+// グローバルなレジストリを使わないため、テストごとに独立したインスタンスを作成できます
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	domainErrors        *prometheus.CounterVec
+	githubRequests      *prometheus.HistogramVec
+	githubRateLimit     *prometheus.GaugeVec
+}
+
+// New はメトリクスを作成し、Goランタイムとプロセスのメトリクスとともに登録します
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "処理したHTTPリクエストの数",
+		}, []string{"method", "route", "status_class"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTPリクエストの処理時間",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status_class"}),
+		domainErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "domain_errors_total",
+			Help:      "エラーハンドラーが分類したエラーの数",
+		}, []string{"kind"}),
+		githubRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "github_request_duration_seconds",
+			Help:      "GitHub APIの呼び出しにかかった時間",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		// 一度も呼び出していない状態を0件と区別するため、ラベル付きにして観測するまで出力しない
+		githubRateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "github_rate_limit_remaining",
+			Help:      "GitHub APIの残りのリクエスト可能数",
+		}, []string{"resource"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.domainErrors,
+		m.githubRequests,
+		m.githubRateLimit,
+	)
+	return m
+}
+
+// Handler は登録されたメトリクスを公開するHTTPハンドラーを返します
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB はコネクションプールの状態 (db.Stats) をdb_nameラベル付きで登録します
+func (m *Metrics) RegisterDB(name string, db *sql.DB) error {
+	if err := m.registry.Register(collectors.NewDBStatsCollector(db, name)); err != nil {
+		return fmt.Errorf("コネクションプールのメトリクスを登録できません: %w", err)
+	}
+	return nil
+}
+
+// ObserveHTTPRequest はHTTPリクエストの処理結果を記録します
+// routeにはパスではなくルートの定義 (/users/:id など) を渡し、ラベルの種類が増えすぎないようにします
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	class := statusClass(status)
+	m.httpRequests.WithLabelValues(method, route, class).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route, class).Observe(duration.Seconds())
+}
+
+// CountDomainError はエラーハンドラーが分類したエラーを種類ごとに数えます
+func (m *Metrics) CountDomainError(kind string) {
+	m.domainErrors.WithLabelValues(kind).Inc()
+}
+
+// ObserveGitHubRequest はGitHub APIの呼び出し時間を操作名と成否ごとに記録します
+func (m *Metrics) ObserveGitHubRequest(operation string, err error, duration time.Duration) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.githubRequests.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
+
+// SetGitHubRateLimitRemaining はGitHub APIの残りのリクエスト可能数をリソース (graphql, coreなど) ごとに記録します
+func (m *Metrics) SetGitHubRateLimitRemaining(resource string, remaining int) {
+	m.githubRateLimit.WithLabelValues(resource).Set(float64(remaining))
+}
+
+// statusClass はステータスコードを 2xx などの区分に変換します
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,80 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/nansystem/go-ddd/internal/metrics"
+)
+
+// scrape は/metricsの出力をテキスト形式で取得します
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected string
+	}{
+		{name: "成功は2xx", status: http.StatusOK, expected: `go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="2xx"} 1`},
+		{name: "クライアントエラーは4xx", status: http.StatusNotFound, expected: `go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="4xx"} 1`},
+		{name: "独自のステータスも区分に丸める", status: 499, expected: `go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="4xx"} 1`},
+		{name: "範囲外のステータスはunknown", status: 0, expected: `go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="unknown"} 1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := metrics.New()
+			m.ObserveHTTPRequest(http.MethodGet, "/users/:id", tt.status, 10*time.Millisecond)
+
+			body := scrape(t, m)
+			assert.Contains(t, body, tt.expected)
+			assert.Contains(t, body, "go_ddd_http_request_duration_seconds_count")
+		})
+	}
+}
+
+func TestMetrics_GitHub(t *testing.T) {
+	m := metrics.New()
+	// 一度も観測していなければ残数は出力しない
+	assert.NotContains(t, scrape(t, m), "go_ddd_github_rate_limit_remaining{")
+
+	m.ObserveGitHubRequest("GetViewer", nil, 100*time.Millisecond)
+	m.ObserveGitHubRequest("GetViewer", errors.New("boom"), 100*time.Millisecond)
+	m.SetGitHubRateLimitRemaining("graphql", 4999)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `go_ddd_github_request_duration_seconds_count{operation="GetViewer",outcome="success"} 1`)
+	assert.Contains(t, body, `go_ddd_github_request_duration_seconds_count{operation="GetViewer",outcome="error"} 1`)
+	assert.Contains(t, body, `go_ddd_github_rate_limit_remaining{resource="graphql"} 4999`)
+}
+
+func TestMetrics_RegisterDB(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := metrics.New()
+	require.NoError(t, m.RegisterDB("sqlite", db))
+	assert.Contains(t, scrape(t, m), `go_sql_max_open_connections{db_name="sqlite"}`)
+
+	// 同じ名前で二重に登録することはできない
+	assert.Error(t, m.RegisterDB("sqlite", db))
+}
@@ -0,0 +1,135 @@
+// Package openapi はOpenAPI 3.1のドキュメントを組み立てます
+// スキーマはリクエスト・レスポンスの型からリフレクションで生成するため、型と仕様が食い違うことはありません
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Version は生成するドキュメントのOpenAPIのバージョンです
+const Version = "3.1.0"
+
+// Document はOpenAPIのドキュメントです
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	// types はコンポーネントとして登録済みの型とその名前です
+	types map[reflect.Type]string
+}
+
+// Info はAPIの概要です
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components は複数の箇所から参照されるスキーマです
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// PathItem はパスごとの操作です (キーは小文字のHTTPメソッド)
+type PathItem map[string]*Operation
+
+// Operation はHTTPメソッドとパスの組に対する操作です
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter はパスパラメータやクエリパラメータです
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody はリクエストボディです
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response はステータスコードごとのレスポンスです
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header はレスポンスヘッダーです
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType はContent-Typeごとのボディの形式です
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// New は空のドキュメントを作成します
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		types:      map[reflect.Type]string{},
+	}
+}
+
+// AddOperation はメソッドとパスに操作を追加します
+// 同じメソッドとパスの操作が既にある場合はエラーを返します
+func (d *Document) AddOperation(method, path string, op *Operation) error {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	key := strings.ToLower(method)
+	if _, ok := item[key]; ok {
+		return fmt.Errorf("操作が重複しています: %s %s", method, path)
+	}
+	item[key] = op
+	return nil
+}
+
+// Operation はメソッドとパスの操作を返します (存在しない場合はnil)
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+// Resolve は$refで参照されているスキーマを返します (参照でない場合はそのまま返す)
+func (d *Document) Resolve(s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	return d.Components.Schemas[strings.TrimPrefix(s.Ref, schemaRefPrefix)]
+}
+
+// EchoPath はEchoのルートのパス (/users/:id) をOpenAPIの形式 (/users/{id}) に変換し、パスパラメータの名前を返します
+func EchoPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, s := range segments {
+		if name, ok := strings.CutPrefix(s, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaRefPrefix はコンポーネントのスキーマを参照する$refの接頭辞です
+const schemaRefPrefix = "#/components/schemas/"
+
+// Schema はJSON Schema (2020-12) のうちAPIの記述に使う部分です
+type Schema struct {
+	Ref         string   `json:"$ref,omitempty"`
+	Type        Types    `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Default     any      `json:"default,omitempty"`
+	Minimum     *int     `json:"minimum,omitempty"`
+	Maximum     *int     `json:"maximum,omitempty"`
+	MinLength   *int     `json:"minLength,omitempty"`
+	MaxLength   *int     `json:"maxLength,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Items       *Schema  `json:"items,omitempty"`
+	// Properties はオブジェクトのプロパティです
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties は未知のプロパティのスキーマです (falseの場合は許可しない)
+	AdditionalProperties any       `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema `json:"allOf,omitempty"`
+}
+
+// Types はJSON Schemaのtypeです
+// OpenAPI 3.1ではnullを許可する場合に複数の型を列挙します
+type Types []string
+
+// MarshalJSON は型が1つの場合は文字列、複数の場合は配列として出力します
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON は文字列と配列のどちらの形式も受け付けます
+func (t *Types) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = Types{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// Has は型にnameが含まれるかどうかを返します
+func (t Types) Has(name string) bool {
+	for _, v := range t {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// SchemaOf はvの型のスキーマを返します
+// 名前付きの構造体はコンポーネントに登録し、$refによる参照を返します
+//
+// 構造体のフィールドはencoding/jsonと同じ規則でプロパティになり、omitemptyでないフィールドは必須になります
+// openapiタグで制約を追加できます (例: `openapi:"minLength=1,maxLength=100,format=email"`)
+// 型を明示する場合は type=string|null のように列挙します
+// openapi:"-" のフィールドはドキュメントに含めません
+// スキーマに変換できない型や不正なタグがある場合はエラーを返します
+func (d *Document) SchemaOf(v any) (*Schema, error) {
+	return d.schema(reflect.TypeOf(v), false)
+}
+
+// ClosedSchemaOf は未知のプロパティを許可しない構造体のスキーマを返します
+// 未知のフィールドを拒否するリクエストボディに使います
+func (d *Document) ClosedSchemaOf(v any) (*Schema, error) {
+	return d.schema(reflect.TypeOf(v), true)
+}
+
+func (d *Document) schema(t reflect.Type, closed bool) (*Schema, error) {
+	if t == nil {
+		return nil, errors.New("openapi: nilはスキーマに変換できません")
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}, nil
+	case rawMessageType:
+		// 任意のJSON値 (型はopenapiタグで指定する)
+		return &Schema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return d.schema(t.Elem(), closed)
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}, nil
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := d.schema(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{"array"}, Items: items}, nil
+	case reflect.Map:
+		values, err := d.schema(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{"object"}, AdditionalProperties: values}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t, closed)
+		}
+		return d.componentRef(t, closed)
+	}
+	// 生成時に型の定義の誤りとして気付けるようにする
+	return nil, fmt.Errorf("openapi: %s はスキーマに変換できません", t)
+}
+
+// componentRef は構造体をコンポーネントに登録し、参照を返します
+func (d *Document) componentRef(t reflect.Type, closed bool) (*Schema, error) {
+	name, ok := d.types[t]
+	if !ok {
+		name = t.Name()
+		if _, exists := d.Components.Schemas[name]; exists {
+			return nil, fmt.Errorf("openapi: スキーマ名 %s が重複しています (%s)", name, t)
+		}
+		d.types[t] = name
+		// 再帰的な型に備えて、プロパティを生成する前に登録する
+		d.Components.Schemas[name] = &Schema{}
+		s, err := d.structSchema(t, closed)
+		if err != nil {
+			// 生成に失敗したスキーマは登録しない
+			delete(d.types, t)
+			delete(d.Components.Schemas, name)
+			return nil, err
+		}
+		*d.Components.Schemas[name] = *s
+	}
+	return &Schema{Ref: schemaRefPrefix + name}, nil
+}
+
+// structSchema は構造体のフィールドからオブジェクトのスキーマを生成します
+func (d *Document) structSchema(t reflect.Type, closed bool) (*Schema, error) {
+	s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}}
+	if closed {
+		s.AdditionalProperties = false
+	}
+	if err := d.addFields(s, t); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// addFields は構造体のフィールドをプロパティとして追加します (埋め込みの構造体は展開する)
+func (d *Document) addFields(s *Schema, t reflect.Type) error {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			if err := d.addFields(s, f.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() || f.Tag.Get("openapi") == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := d.schema(f.Type, false)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		if opt := f.Tag.Get("openapi"); opt != "" {
+			if prop.Ref != "" {
+				return fmt.Errorf("openapi: 構造体のフィールド %s.%s にはopenapiタグを指定できません", t, f.Name)
+			}
+			if err := applyTag(prop, opt); err != nil {
+				return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+			}
+		}
+		s.Properties[name] = prop
+		if !hasOption(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return nil
+}
+
+// applyTag はopenapiタグの制約をスキーマに反映します
+func applyTag(s *Schema, tag string) error {
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(opt, "=")
+		var err error
+		switch key {
+		case "type":
+			s.Type = strings.Split(value, "|")
+		case "format":
+			s.Format = value
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "minimum":
+			s.Minimum, err = atoi(key, value)
+		case "maximum":
+			s.Maximum, err = atoi(key, value)
+		case "minLength":
+			s.MinLength, err = atoi(key, value)
+		case "maxLength":
+			s.MaxLength, err = atoi(key, value)
+		case "pattern":
+			s.Pattern = value
+		default:
+			err = fmt.Errorf("openapi: 不明なタグ %q", opt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func atoi(key, value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s の値 %q は整数ではありません", key, value)
+	}
+	return &n, nil
+}
+
+func hasOption(opts, name string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Int は整数の制約を指定するためのヘルパーです
+func Int(n int) *int {
+	return &n
+}
@@ -0,0 +1,195 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/openapi"
+)
+
+type testItem struct {
+	Value int `json:"value"`
+}
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testRequest struct {
+	testBase
+	Name      string          `json:"name" openapi:"minLength=1,maxLength=10"`
+	Code      string          `json:"code,omitempty" openapi:"pattern=\\S"`
+	Note      string          `json:"note,omitempty"`
+	Email     json.RawMessage `json:"email,omitempty" openapi:"type=string|null,format=email"`
+	Items     []testItem      `json:"items"`
+	CreatedAt time.Time       `json:"created_at"`
+	Internal  string          `json:"-"`
+	Ignored   string          `json:"ignored" openapi:"-"`
+	hidden    string
+}
+
+func TestDocument_SchemaOf(t *testing.T) {
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+
+	ref, err := doc.ClosedSchemaOf(testRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/testRequest", ref.Ref)
+
+	s := doc.Resolve(ref)
+	require.NotNil(t, s)
+	assert.Equal(t, openapi.Types{"object"}, s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	// 埋め込みの構造体は展開し、json:"-"、openapi:"-"と非公開のフィールドは含めない
+	assert.ElementsMatch(t, []string{"id", "name", "code", "note", "email", "items", "created_at"}, keys(s.Properties))
+	// omitemptyでないフィールドは必須
+	assert.Equal(t, []string{"id", "name", "items", "created_at"}, s.Required)
+
+	assert.Equal(t, &openapi.Schema{Type: openapi.Types{"string"}, MinLength: openapi.Int(1), MaxLength: openapi.Int(10)}, s.Properties["name"])
+	assert.Equal(t, &openapi.Schema{Type: openapi.Types{"string"}, Pattern: `\S`}, s.Properties["code"])
+	assert.Equal(t, &openapi.Schema{Type: openapi.Types{"string", "null"}, Format: "email"}, s.Properties["email"])
+	assert.Equal(t, &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}, s.Properties["created_at"])
+
+	// 要素の構造体はコンポーネントとして参照する (未知のプロパティは許可する)
+	items := s.Properties["items"]
+	assert.Equal(t, openapi.Types{"array"}, items.Type)
+	item := doc.Resolve(items.Items)
+	require.NotNil(t, item)
+	assert.Nil(t, item.AdditionalProperties)
+	assert.Equal(t, []string{"value"}, item.Required)
+
+	// 同じ型は同じコンポーネントを参照する
+	itemRef, err := doc.SchemaOf(testItem{})
+	require.NoError(t, err)
+	assert.Equal(t, items.Items, itemRef)
+	assert.Len(t, doc.Components.Schemas, 2)
+}
+
+type testInvalid struct {
+	Name string `json:"name" openapi:"minLength=one"`
+}
+
+func TestDocument_SchemaOf_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name: "整数でない制約",
+			value: struct {
+				Name string `json:"name" openapi:"minLength=one"`
+			}{},
+			expected: `minLength の値 "one" は整数ではありません`,
+		},
+		{
+			name: "不明なタグ",
+			value: struct {
+				Name string `json:"name" openapi:"minLen=1"`
+			}{},
+			expected: `不明なタグ "minLen=1"`,
+		},
+		{
+			name: "スキーマに変換できない型",
+			value: struct {
+				Done chan struct{} `json:"done"`
+			}{},
+			expected: "chan struct {} はスキーマに変換できません",
+		},
+		{
+			name: "構造体のフィールドへのタグ",
+			value: struct {
+				Item testItem `json:"item" openapi:"minLength=1"`
+			}{},
+			expected: "openapiタグを指定できません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+			_, err := doc.SchemaOf(tt.value)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestDocument_SchemaOf_ErrorDoesNotRegisterComponent(t *testing.T) {
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+	_, err := doc.SchemaOf(testInvalid{})
+	require.Error(t, err)
+	// 生成に失敗した構造体はコンポーネントに残さない
+	assert.Empty(t, doc.Components.Schemas)
+}
+
+func TestTypes_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		types openapi.Types
+		json  string
+	}{
+		{name: "型が1つなら文字列", types: openapi.Types{"string"}, json: `"string"`},
+		{name: "複数なら配列", types: openapi.Types{"string", "null"}, json: `["string","null"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.types)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(b))
+
+			var got openapi.Types
+			require.NoError(t, json.Unmarshal(b, &got))
+			assert.Equal(t, tt.types, got)
+		})
+	}
+}
+
+func TestEchoPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		params   []string
+	}{
+		{path: "/users", expected: "/users"},
+		{path: "/users/:id", expected: "/users/{id}", params: []string{"id"}},
+		{path: "/orgs/:org/users/:id", expected: "/orgs/{org}/users/{id}", params: []string{"org", "id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, params := openapi.EchoPath(tt.path)
+			assert.Equal(t, tt.expected, path)
+			assert.Equal(t, tt.params, params)
+		})
+	}
+}
+
+func TestDocument_AddOperation(t *testing.T) {
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+	op := &openapi.Operation{OperationID: "getUser"}
+
+	require.NoError(t, doc.AddOperation("GET", "/users/{id}", op))
+	assert.Same(t, op, doc.Operation("GET", "/users/{id}"))
+	assert.Nil(t, doc.Operation("DELETE", "/users/{id}"))
+	assert.Error(t, doc.AddOperation("GET", "/users/{id}", &openapi.Operation{}))
+}
+
+func TestViewer(t *testing.T) {
+	html, err := openapi.Viewer("API <test>", "/openapi.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "API &lt;test&gt;")
+	assert.Contains(t, string(html), `fetch("/openapi.json")`)
+}
+
+func keys(m map[string]*openapi.Schema) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
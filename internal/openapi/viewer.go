@@ -0,0 +1,22 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed viewer.html
+var viewerHTML string
+
+var viewerTemplate = template.Must(template.New("viewer").Parse(viewerHTML))
+
+// Viewer はspecURLのドキュメントを表示するHTMLを返します
+// 外部のスクリプトを読み込まないため、オフラインの環境でも表示できます
+func Viewer(title, specURL string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := viewerTemplate.Execute(&buf, struct{ Title, SpecURL string }{title, specURL}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package presentation_test
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/presentation"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+var updateContract = flag.Bool("update", false, "testdata/contract のゴールデンファイルを更新する")
+
+// TestResponseContractV1 はレスポンスの形式がv1の契約 (testdata/contract/v1) と一致することを検証します
+// 意図した変更の場合のみ -update で更新し、互換性を壊す変更は新しいバージョンとして追加します
+func TestResponseContractV1(t *testing.T) {
+	u := &user.User{ID: "1", Name: "テストユーザー1", Email: mustEmail("test1@example.com")}
+	cursor := &user.Cursor{Sort: user.Sort{Field: user.SortByName}, Key: u.Name, ID: u.ID}
+
+	tests := []struct {
+		name      string
+		golden    string
+		method    string
+		target    string
+		body      string
+		setupMock func(mockService *usecase.MockUserService)
+	}{
+		{
+			name:   "ユーザー一覧",
+			golden: "user_list.json",
+			method: http.MethodGet,
+			target: "/users?sort=name&limit=1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				page := &user.Page{Users: []*user.User{u}, NextCursor: cursor}
+				mockService.On("GetUsers", mock.Anything, mock.Anything).Return(page, nil).Once()
+			},
+		},
+		{
+			name:   "ユーザー",
+			golden: "user.json",
+			method: http.MethodGet,
+			target: "/users/1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("GetUserByID", mock.Anything, "1").Return(u, nil).Once()
+			},
+		},
+		{
+			name:   "ユーザー作成",
+			golden: "user_created.json",
+			method: http.MethodPost,
+			target: "/users",
+			body:   `{"name":"テストユーザー1","email":"test1@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*user.User).ID = "1"
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(usecase.MockUserService)
+			tt.setupMock(mockService)
+			e := setupTestRouter(presentation.NewUserHandler(mockService))
+
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			require.Less(t, rec.Code, http.StatusBadRequest, rec.Body.String())
+
+			path := filepath.Join("testdata", "contract", "v1", tt.golden)
+			if *updateContract {
+				require.NoError(t, os.WriteFile(path, rec.Body.Bytes(), 0o644))
+			}
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(golden), rec.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+)
+
+// MaxRequestBodyBytes はリクエストボディの最大サイズです
+const MaxRequestBodyBytes = 64 << 10
+
+// MIMEApplicationMergePatchJSON はJSON Merge Patch (RFC 7396) のContent-Typeです
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// decodeJSON はContent-TypeがmediaTypeのリクエストボディをdstに厳密にデコードします
+// 異なるContent-Type、JSONオブジェクト以外、未知のフィールド、上限を超えるサイズ、複数のJSON値を含むボディは拒否します
+func decodeJSON(c echo.Context, dst any, mediaType string) error {
+	if t, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType)); err != nil || t != mediaType {
+		return echo.ErrUnsupportedMediaType
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, MaxRequestBodyBytes)
+	dec := json.NewDecoder(body)
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return decodeError(err)
+	}
+	// 1つのJSON値の後に続くデータは受け付けない
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		if err == nil {
+			return echo.ErrBadRequest
+		}
+		return decodeError(err)
+	}
+	// nullは構造体へのデコードでは何もせず成功するため、ここで拒否する
+	// (Merge Patchではnullのボディを全項目の削除と区別できない)
+	if raw[0] != '{' {
+		return echo.NewHTTPError(http.StatusBadRequest, "request body must be a JSON object")
+	}
+
+	strict := json.NewDecoder(bytes.NewReader(raw))
+	strict.DisallowUnknownFields()
+	if err := strict.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// decodeError はデコードのエラーをクライアントに返すエラーに変換します
+func decodeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge).WithInternal(err)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return domainerror.NewValidationError(typeErr.Field, domainerror.MsgInvalidType)
+	default:
+		if field, ok := unknownField(err); ok {
+			return domainerror.NewValidationError(field, domainerror.MsgUnknownField)
+		}
+		return echo.ErrBadRequest.WithInternal(err)
+	}
+}
+
+// unknownFieldPrefix はDisallowUnknownFieldsで未知のフィールドを拒否したときのエラーメッセージの接頭辞です
+const unknownFieldPrefix = "json: unknown field "
+
+// unknownField は未知のフィールドによるデコードのエラーであれば、その項目名を返します
+// encoding/jsonは未知のフィールドを専用のエラー型で返さないため、メッセージから取り出します
+// メッセージの形式はdecode_test.goで固定しています
+func unknownField(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix)
+	if !ok {
+		return "", false
+	}
+	field, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", false
+	}
+	return field, true
+}
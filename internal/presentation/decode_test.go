@@ -0,0 +1,58 @@
+package presentation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownField(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectedError string
+		expectedField string
+		expectedOK    bool
+	}{
+		{
+			name:          "未知のフィールド",
+			body:          `{"nickname":"x"}`,
+			expectedError: `json: unknown field "nickname"`,
+			expectedField: "nickname",
+			expectedOK:    true,
+		},
+		{
+			name:          "引用符を含む項目名",
+			body:          `{"a\"b":"x"}`,
+			expectedError: `json: unknown field "a\"b"`,
+			expectedField: `a"b`,
+			expectedOK:    true,
+		},
+		{
+			name:          "未知のフィールド以外のエラー",
+			body:          `{"name":}`,
+			expectedError: "invalid character '}' looking for beginning of value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.body))
+			dec.DisallowUnknownFields()
+			err := dec.Decode(&struct {
+				Name string `json:"name"`
+			}{})
+
+			// encoding/jsonのメッセージの形式が変わった場合はここで検出する
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+
+			field, ok := unknownField(err)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedField, field)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package presentation
+
+import (
+	"encoding/json"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+// このファイルはAPIのリクエスト・レスポンスの形式 (v1) を定義します
+// ドメインモデルの変更がクライアントに影響しないよう、ハンドラーはドメインモデルを直接シリアライズせず、これらの型に変換します
+// 既存のフィールドの名前・型・意味を変更する場合は互換性が失われるため、新しいバージョンの型を追加します
+// 形式はtestdata/contract/v1のゴールデンファイルで固定しています
+// openapiタグはAPIドキュメント (/openapi.json) に出力する制約で、ドメインの検証規則に合わせます
+// 名前は前後の空白を除いて空でないことが必須のため、長さではなく空白以外の文字を含むパターン (\S) で表します
+
+// UserResponse はユーザーのレスポンスです
+type UserResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// newUserResponse はドメインモデルをレスポンスに変換します
+func newUserResponse(u *user.User) UserResponse {
+	return UserResponse{ID: u.ID, Name: u.Name, Email: u.Email.String()}
+}
+
+// UserListResponse はユーザー一覧のレスポンスです
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
+	// NextCursor は次のページを取得するためのカーソルです (最後のページでは省略)
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// newUserListResponse はユーザー一覧のページをレスポンスに変換します
+func newUserListResponse(page *user.Page) UserListResponse {
+	res := UserListResponse{Users: make([]UserResponse, 0, len(page.Users))}
+	for _, u := range page.Users {
+		res.Users = append(res.Users, newUserResponse(u))
+	}
+	if page.NextCursor != nil {
+		res.NextCursor = page.NextCursor.Encode()
+	}
+	return res
+}
+
+// CreateUserRequest はユーザー作成のリクエストです
+type CreateUserRequest struct {
+	// ID はサーバーで採番するため、指定された場合は拒否します
+	// クライアントが指定する項目ではないためドキュメントには含めません
+	ID    string `json:"id,omitempty" openapi:"-"`
+	Name  string `json:"name" openapi:"pattern=\\S,maxLength=100"`
+	Email string `json:"email" openapi:"format=email"`
+}
+
+// toCommand はリクエストをユーザー作成の入力に変換します
+func (r CreateUserRequest) toCommand() user.CreateCommand {
+	return user.CreateCommand{ID: r.ID, Name: r.Name, Email: r.Email}
+}
+
+// CreateUserResponse はユーザー作成のレスポンスです
+type CreateUserResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// UpdateUserRequest はユーザーの全項目を置き換える更新のリクエストです
+type UpdateUserRequest struct {
+	Name  string `json:"name" openapi:"pattern=\\S,maxLength=100"`
+	Email string `json:"email" openapi:"format=email"`
+}
+
+// toCommand はリクエストを更新の入力に変換します (IDはパスパラメータを正とする)
+func (r UpdateUserRequest) toCommand(id string) user.UpdateCommand {
+	return user.UpdateCommand{ID: id, Name: r.Name, Email: r.Email}
+}
+
+// PatchUserRequest はJSON Merge Patch (RFC 7396) によるユーザーの部分更新のリクエストです
+// キーが存在しない場合とnullの場合を区別するためRawMessageで受け取ります
+type PatchUserRequest struct {
+	Name  json.RawMessage `json:"name,omitempty" openapi:"type=string,pattern=\\S,maxLength=100"`
+	Email json.RawMessage `json:"email,omitempty" openapi:"type=string,format=email"`
+}
+
+// toCommand はリクエストを部分更新の入力に変換します
+// 必須項目のnullによる削除は、空文字列として検証で拒否します
+func (r PatchUserRequest) toCommand() (user.PatchCommand, error) {
+	var cmd user.PatchCommand
+	var errs domainerror.ValidationErrors
+	for _, f := range []struct {
+		field string
+		raw   json.RawMessage
+		dst   **string
+	}{
+		{"name", r.Name, &cmd.Name},
+		{"email", r.Email, &cmd.Email},
+	} {
+		if f.raw == nil {
+			continue
+		}
+		var v string
+		if string(f.raw) != "null" {
+			if err := json.Unmarshal(f.raw, &v); err != nil {
+				errs = append(errs, domainerror.NewValidationError(f.field, domainerror.MsgInvalidType))
+				continue
+			}
+		}
+		*f.dst = &v
+	}
+	if len(errs) > 0 {
+		return cmd, errs
+	}
+	return cmd, nil
+}
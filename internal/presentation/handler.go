@@ -1,73 +1,220 @@
 package presentation
 
 import (
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
 	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/i18n"
+	"github.com/nansystem/go-ddd/internal/logging"
 	"github.com/nansystem/go-ddd/internal/usecase"
 )
 
+// msgUserCreated はユーザー作成のレスポンスのメッセージキーです
+const msgUserCreated = "user.created"
+
 type UserHandler struct {
 	userService usecase.UserServiceInterface
+	// catalog はレスポンスのメッセージの翻訳に使うカタログです
+	catalog *i18n.Catalog
 }
 
 func NewUserHandler(userService usecase.UserServiceInterface) *UserHandler {
-	return &UserHandler{userService: userService}
+	return &UserHandler{userService: userService, catalog: i18n.Default()}
 }
 
+// GetUsers はユーザー一覧を返します
+// クエリパラメータ: limit, cursor, sort (name, -name, created_at, -created_at),
+// name_prefix, created_from, created_to (RFC 3339)
 func (h *UserHandler) GetUsers(c echo.Context) error {
-	users, err := h.userService.GetUsers()
+	query, err := parseUserQuery(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.userService.GetUsers(c.Request().Context(), query)
 	if err != nil {
 		return err // エラーをそのまま返す
 	}
-	return c.JSON(http.StatusOK, users)
+
+	return c.JSON(http.StatusOK, newUserListResponse(page))
+}
+
+// parseUserQuery はクエリパラメータを一覧の検索仕様に変換します
+// 値の範囲などの検証はユースケースでQuery.Validateにより行います
+func parseUserQuery(c echo.Context) (user.Query, error) {
+	query := user.NewQuery()
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return query, domainerror.NewValidationError("limit", domainerror.MsgMustBeInteger)
+		}
+		query.Limit = limit
+	}
+
+	if v := c.QueryParam("cursor"); v != "" {
+		cursor, err := user.DecodeCursor(v)
+		if err != nil {
+			return query, err
+		}
+		query.After = cursor
+		// sortを省略した場合はカーソル発行時の並び順を引き継ぐ
+		query.Sort = cursor.Sort
+	}
+
+	if v := c.QueryParam("sort"); v != "" {
+		field, desc := strings.CutPrefix(v, "-")
+		query.Sort = user.Sort{Field: user.SortField(field), Desc: desc}
+	}
+
+	query.NamePrefix = c.QueryParam("name_prefix")
+
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+	} {
+		v := c.QueryParam(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return query, domainerror.NewValidationError(p.name, domainerror.MsgMustBeDateTime)
+		}
+		*p.dst = t
+	}
+
+	return query, nil
 }
 
 func (h *UserHandler) GetUserByID(c echo.Context) error {
-	id := c.Param("id")
-	user, err := h.userService.GetUserByID(id)
+	id := userIDParam(c)
+	u, err := h.userService.GetUserByID(c.Request().Context(), id)
 	if err != nil {
 		return err // エラーをそのまま返す
 	}
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, newUserResponse(u))
 }
 
 func (h *UserHandler) CreateUser(c echo.Context) error {
-	// リクエストボディのバインディングはハンドラで行うのが一般的
-	reqUser := new(struct { // DTOを定義する方が望ましい場合もある
-		ID    string `json:"id"` // Create時はIDは不要か、自動生成するべき
-		Name  string `json:"name"`
-		Email string `json:"email"` // UserドメインモデルにEmailがなければ不要
-	})
-	if err := c.Bind(reqUser); err != nil {
-		// バインドエラーはBadRequestとしてミドルウェアに処理させるか、
-		// ここで具体的なエラーを返す（ただし、ミドルウェアの思想とは少しずれる）
-		// return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
-		return err // シンプルにミドルウェアに任せる
-	}
-
-	// ドメインモデルに変換 (Emailはドメインモデルに合わせて調整)
-	domainUser := &user.User{
-		ID:   reqUser.ID, // IDの扱いは要検討
-		Name: reqUser.Name,
-		// Email: reqUser.Email, // 必要なら追加
-	}
-
-	if err := h.userService.CreateUser(domainUser); err != nil {
+	var req CreateUserRequest
+	if err := decodeJSON(c, &req, echo.MIMEApplicationJSON); err != nil {
+		return err
+	}
+
+	// すべての項目を検証し、違反はまとめて返す
+	cmd := req.toCommand()
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+	// ドメインモデルに変換 (IDはユースケースで採番される)
+	domainUser, err := cmd.ToUser()
+	if err != nil {
+		return err
+	}
+
+	if err := h.userService.CreateUser(c.Request().Context(), domainUser); err != nil {
 		return err // エラーをそのまま返す
 	}
 
-	// 成功レスポンス (message フィールドを追加)
-	return c.JSON(http.StatusCreated, map[string]string{
-		"id":      domainUser.ID,
-		"message": "ユーザーが作成されました",
-	})
+	logging.AddAttrs(c.Request().Context(), slog.String("user_id", domainUser.ID))
+
+	// 作成したリソースのURIをLocationヘッダーで返す
+	c.Response().Header().Set(echo.HeaderLocation, c.Request().URL.Path+"/"+domainUser.ID)
+
+	// メッセージはエラーと同じくAccept-Languageで選んだ言語で返す
+	lang := h.catalog.Negotiate(c.Request().Header.Get("Accept-Language"))
+	c.Response().Header().Set("Content-Language", lang)
+	return c.JSON(http.StatusCreated, CreateUserResponse{ID: domainUser.ID, Message: h.catalog.Translate(lang, msgUserCreated, nil)})
+}
+
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	id := userIDParam(c)
+	var req UpdateUserRequest
+	if err := decodeJSON(c, &req, echo.MIMEApplicationJSON); err != nil {
+		return err
+	}
+
+	// IDはパスパラメータを正とする
+	cmd := req.toCommand(id)
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+	domainUser, err := cmd.ToUser()
+	if err != nil {
+		return err
+	}
+
+	if err := h.userService.UpdateUser(c.Request().Context(), domainUser); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserResponse(domainUser))
+}
+
+// PatchUser はJSON Merge Patch (RFC 7396) でユーザーを部分更新します
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	id := userIDParam(c)
+
+	var req PatchUserRequest
+	if err := decodeJSON(c, &req, MIMEApplicationMergePatchJSON); err != nil {
+		return err
+	}
+	cmd, err := req.toCommand()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	patch := &usecase.UserPatch{Name: cmd.Name}
+	if cmd.Email != nil {
+		email, err := user.NewEmail(*cmd.Email)
+		if err != nil {
+			return err
+		}
+		patch.Email = &email
+	}
+
+	updated, err := h.userService.PatchUser(c.Request().Context(), id, patch)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserResponse(updated))
+}
+
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	id := userIDParam(c)
+	if err := h.userService.DeleteUser(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// userIDParam はパスパラメータのユーザーIDを返し、リクエストのログに記録します
+func userIDParam(c echo.Context) string {
+	id := c.Param("id")
+	logging.AddAttrs(c.Request().Context(), slog.String("user_id", id))
+	return id
 }
 
+// SetupUserRoutes はユーザーのエンドポイントを登録します
+// ルートの名前はAPIドキュメントのoperationIdで、userOperationsの仕様と対応づけます
 func (h *UserHandler) SetupUserRoutes(g *echo.Group) {
-	g.GET("", h.GetUsers)
-	g.GET("/:id", h.GetUserByID)
-	g.POST("", h.CreateUser)
+	g.GET("", h.GetUsers).Name = "listUsers"
+	g.GET("/:id", h.GetUserByID).Name = "getUser"
+	g.POST("", h.CreateUser).Name = "createUser"
+	g.PUT("/:id", h.UpdateUser).Name = "updateUser"
+	g.PATCH("/:id", h.PatchUser).Name = "patchUser"
+	g.DELETE("/:id", h.DeleteUser).Name = "deleteUser"
 }
@@ -2,13 +2,19 @@ package presentation_test
 
 import (
 	"bytes" // JSONEqのために必要
+	"cmp"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/nansystem/go-ddd/internal/domain/domainerror"
 	"github.com/nansystem/go-ddd/internal/domain/user"
@@ -30,42 +36,130 @@ func setupTestRouter(handler *presentation.UserHandler) *echo.Echo {
 	return e
 }
 
+// mustEmail はテスト用に検証済みのEmailを作成します
+func mustEmail(s string) user.Email {
+	email, err := user.NewEmail(s)
+	if err != nil {
+		panic(err)
+	}
+	return email
+}
+
 func TestGetUsers(t *testing.T) {
+	cursor := &user.Cursor{Sort: user.Sort{Field: user.SortByName}, Key: "テストユーザー2", ID: "2"}
+
 	tests := []struct {
 		name           string
+		target         string
 		setupMock      func(mockService *usecase.MockUserService)
 		expectedStatus int
 		expectedBody   string // 期待するJSON文字列
 	}{
 		{
-			name: "成功: ユーザー一覧を取得",
+			name:   "成功: ユーザー一覧を取得",
+			target: "/users",
+			setupMock: func(mockService *usecase.MockUserService) {
+				page := &user.Page{Users: []*user.User{
+					{ID: "1", Name: "テストユーザー1", Email: mustEmail("test1@example.com")},
+					{ID: "2", Name: "テストユーザー2", Email: mustEmail("test2@example.com")},
+				}}
+				mockService.On("GetUsers", mock.Anything, user.NewQuery()).Return(page, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"users":[{"id":"1","name":"テストユーザー1","email":"test1@example.com"},{"id":"2","name":"テストユーザー2","email":"test2@example.com"}]}`,
+		},
+		{
+			name:   "成功: 次ページがある場合はnext_cursorを返す",
+			target: "/users?limit=2&sort=name&name_prefix=テスト&created_from=2025-01-01T00:00:00Z&created_to=2026-01-01T00:00:00Z",
 			setupMock: func(mockService *usecase.MockUserService) {
-				users := []*user.User{
-					{ID: "1", Name: "テストユーザー1"},
-					{ID: "2", Name: "テストユーザー2"},
+				query := user.Query{
+					Limit:       2,
+					Sort:        user.Sort{Field: user.SortByName},
+					NamePrefix:  "テスト",
+					CreatedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					CreatedTo:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
 				}
-				mockService.On("GetUsers").Return(users, nil).Once()
+				page := &user.Page{
+					Users: []*user.User{
+						{ID: "1", Name: "テストユーザー1", Email: mustEmail("test1@example.com")},
+						{ID: "2", Name: "テストユーザー2", Email: mustEmail("test2@example.com")},
+					},
+					NextCursor: cursor,
+				}
+				mockService.On("GetUsers", mock.Anything, query).Return(page, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"users":[{"id":"1","name":"テストユーザー1","email":"test1@example.com"},{"id":"2","name":"テストユーザー2","email":"test2@example.com"}],"next_cursor":"` + cursor.Encode() + `"}`,
+		},
+		{
+			name:   "成功: カーソル指定時はカーソルの並び順を引き継ぐ",
+			target: "/users?limit=2&cursor=" + cursor.Encode(),
+			setupMock: func(mockService *usecase.MockUserService) {
+				query := user.Query{Limit: 2, Sort: cursor.Sort, After: cursor}
+				mockService.On("GetUsers", mock.Anything, query).Return(&user.Page{Users: []*user.User{}}, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"ID":"1","Name":"テストユーザー1"},{"ID":"2","Name":"テストユーザー2"}]`,
+			expectedBody:   `{"users":[]}`,
+		},
+		{
+			name:           "失敗: limitが整数でない",
+			target:         "/users?limit=ten",
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field limit: 整数で指定してください","fields":[{"name":"limit","reason":"整数で指定してください"}]}`,
+		},
+		{
+			name:           "失敗: 不正なカーソル",
+			target:         "/users?cursor=broken",
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field cursor: カーソルが不正です","fields":[{"name":"cursor","reason":"カーソルが不正です"}]}`,
+		},
+		{
+			name:           "失敗: 日時の形式が不正",
+			target:         "/users?created_from=2025-01-01",
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field created_from: RFC 3339形式の日時で指定してください","fields":[{"name":"created_from","reason":"RFC 3339形式の日時で指定してください"}]}`,
 		},
 		{
-			name: "失敗: ユースケースでエラー発生",
+			name:   "失敗: ユースケースでエラー発生",
+			target: "/users",
 			setupMock: func(mockService *usecase.MockUserService) {
 				// 内部エラーをシミュレート (DBエラーなど)
-				mockService.On("GetUsers").Return(nil, errors.New("予期せぬ内部エラー")).Once()
+				mockService.On("GetUsers", mock.Anything, mock.Anything).Return(nil, errors.New("予期せぬ内部エラー")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError, // ミドルウェアが500を返す
-			expectedBody:   `{"error":"internal_server_error","message":"内部エラーが発生しました"}`,
+			expectedBody:   `{"error":"internal_server_error","code":"internal_server_error","message":"内部エラーが発生しました"}`,
+		},
+		{
+			name:   "失敗: クエリがタイムアウト",
+			target: "/users",
+			setupMock: func(mockService *usecase.MockUserService) {
+				timeoutErr := fmt.Errorf("%w: %w", domainerror.ErrTimeout, context.DeadlineExceeded)
+				mockService.On("GetUsers", mock.Anything, mock.Anything).Return(nil, timeoutErr).Once()
+			},
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedBody:   `{"error":"timeout","code":"timeout","message":"処理がタイムアウトしました"}`,
+		},
+		{
+			name:   "失敗: クライアントがリクエストをキャンセル",
+			target: "/users",
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("GetUsers", mock.Anything, mock.Anything).Return(nil, context.Canceled).Once()
+			},
+			expectedStatus: middleware.StatusClientClosedRequest,
+			expectedBody:   `{"error":"client_closed_request","code":"client_closed_request","message":"リクエストがキャンセルされました"}`,
 		},
 		{
-			name: "成功: ユーザーが0件の場合",
+			name:   "成功: ユーザーが0件の場合",
+			target: "/users",
 			setupMock: func(mockService *usecase.MockUserService) {
-				users := []*user.User{} // 空のスライス
-				mockService.On("GetUsers").Return(users, nil).Once()
+				page := &user.Page{Users: []*user.User{}} // 空のスライス
+				mockService.On("GetUsers", mock.Anything, user.NewQuery()).Return(page, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[]`, // 空のJSON配列
+			expectedBody:   `{"users":[]}`, // 空のJSON配列
 		},
 	}
 
@@ -76,7 +170,7 @@ func TestGetUsers(t *testing.T) {
 			handler := presentation.NewUserHandler(mockService)
 			e := setupTestRouter(handler)
 
-			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
 			rec := httptest.NewRecorder()
 			e.ServeHTTP(rec, req)
 
@@ -99,30 +193,30 @@ func TestGetUserByID(t *testing.T) {
 			name:   "成功: 存在するユーザーID",
 			userID: "1",
 			setupMock: func(mockService *usecase.MockUserService, id string) {
-				user := &user.User{ID: id, Name: "テストユーザー1"}
-				mockService.On("GetUserByID", id).Return(user, nil).Once()
+				user := &user.User{ID: id, Name: "テストユーザー1", Email: mustEmail("test1@example.com")}
+				mockService.On("GetUserByID", mock.Anything, id).Return(user, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"ID":"1","Name":"テストユーザー1"}`,
+			expectedBody:   `{"id":"1","name":"テストユーザー1","email":"test1@example.com"}`,
 		},
 		{
 			name:   "失敗: 存在しないユーザーID",
 			userID: "notfound",
 			setupMock: func(mockService *usecase.MockUserService, id string) {
 				notFoundErr := domainerror.NewNotFoundError("User", id)
-				mockService.On("GetUserByID", id).Return(nil, notFoundErr).Once()
+				mockService.On("GetUserByID", mock.Anything, id).Return(nil, notFoundErr).Once()
 			},
 			expectedStatus: http.StatusNotFound, // ミドルウェアが404を返す
-			expectedBody:   `{"error":"not_found","message":"User (ID: notfound) エンティティが見つかりません"}`,
+			expectedBody:   `{"error":"not_found","code":"not_found","message":"User (ID: notfound) エンティティが見つかりません"}`,
 		},
 		{
 			name:   "失敗: ユースケースで内部エラー発生",
 			userID: "internalerror",
 			setupMock: func(mockService *usecase.MockUserService, id string) {
-				mockService.On("GetUserByID", id).Return(nil, errors.New("内部エラー発生")).Once()
+				mockService.On("GetUserByID", mock.Anything, id).Return(nil, errors.New("内部エラー発生")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError, // ミドルウェアが500を返す
-			expectedBody:   `{"error":"internal_server_error","message":"内部エラーが発生しました"}`,
+			expectedBody:   `{"error":"internal_server_error","code":"internal_server_error","message":"内部エラーが発生しました"}`,
 		},
 	}
 
@@ -147,22 +241,49 @@ func TestGetUserByID(t *testing.T) {
 
 func TestCreateUser(t *testing.T) {
 	tests := []struct {
-		name           string
-		requestBody    string
-		setupMock      func(mockService *usecase.MockUserService)
-		expectedStatus int
-		expectedBody   string
+		name             string
+		requestBody      string
+		contentType      string
+		acceptLanguage   string
+		setupMock        func(mockService *usecase.MockUserService)
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
 	}{
 		{
 			name:        "成功: ユーザーを作成",
-			requestBody: `{"id":"newid","name":"新規ユーザー"}`,
+			requestBody: `{"name":"新規ユーザー","email":"new@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				// CreateUserに渡されるであろうUserオブジェクトを期待値として設定 (IDは未採番)
+				expectedUser := &user.User{Name: "新規ユーザー", Email: mustEmail("new@example.com")}
+				mockService.On("CreateUser", mock.Anything, expectedUser).Run(func(args mock.Arguments) {
+					// ユースケースによる採番をシミュレート
+					args.Get(1).(*user.User).ID = "generated-id"
+				}).Return(nil).Once()
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     `{"id":"generated-id","message":"ユーザーが作成されました"}`, // handlerの実装に合わせる
+			expectedLocation: "/users/generated-id",
+		},
+		{
+			name:           "成功: メッセージはAccept-Languageの言語で返す",
+			requestBody:    `{"name":"新規ユーザー","email":"new@example.com"}`,
+			acceptLanguage: "en",
 			setupMock: func(mockService *usecase.MockUserService) {
-				// CreateUserに渡されるであろうUserオブジェクトを期待値として設定
-				expectedUser := &user.User{ID: "newid", Name: "新規ユーザー"}
-				mockService.On("CreateUser", expectedUser).Return(nil).Once()
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*user.User).ID = "generated-id"
+				}).Return(nil).Once()
 			},
-			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"id":"newid","message":"ユーザーが作成されました"}`, // handlerの実装に合わせる
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     `{"id":"generated-id","message":"User created"}`,
+			expectedLocation: "/users/generated-id",
+		},
+		{
+			name:           "失敗: クライアントがIDを指定",
+			requestBody:    `{"id":"clientid","name":"新規ユーザー","email":"new@example.com"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field id: IDはサーバーで採番されるため指定できません","fields":[{"name":"id","reason":"IDはサーバーで採番されるため指定できません"}]}`,
 		},
 		{
 			name:        "失敗: 不正なリクエストボディ (JSON)",
@@ -173,39 +294,107 @@ func TestCreateUser(t *testing.T) {
 			expectedStatus: http.StatusBadRequest, // EchoのデフォルトのBindエラーは400
 			// Echoのデフォルトエラーレスポンスか、ミドルウェアのレスポンスを期待
 			// ここではミドルウェアが echo.ErrBadRequest を捕捉することを期待
-			expectedBody: `{"error":"bad_request","message":"不正なリクエストです"}`,
+			expectedBody: `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+		{
+			name:           "失敗: JSON以外のContent-Type",
+			requestBody:    `{"name":"新規ユーザー","email":"new@example.com"}`,
+			contentType:    echo.MIMETextPlain,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"unsupported_media_type","code":"unsupported_media_type","message":"対応していないContent-Typeです"}`,
+		},
+		{
+			name:           "失敗: charset付きのJSONも受け付けて検証する",
+			requestBody:    `{"name":"","email":"new@example.com"}`,
+			contentType:    echo.MIMEApplicationJSONCharsetUTF8,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 名前は必須です","fields":[{"name":"name","reason":"名前は必須です"}]}`,
+		},
+		{
+			name:           "失敗: 未知のフィールド",
+			requestBody:    `{"name":"新規ユーザー","email":"new@example.com","role":"admin"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field role: 未知の項目です","fields":[{"name":"role","reason":"未知の項目です"}]}`,
+		},
+		{
+			name:           "失敗: フィールドの型が不正",
+			requestBody:    `{"name":123,"email":"new@example.com"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 型が不正です","fields":[{"name":"name","reason":"型が不正です"}]}`,
+		},
+		{
+			name:           "失敗: JSON値の後に続くデータ",
+			requestBody:    `{"name":"新規ユーザー","email":"new@example.com"}{}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+		{
+			name:           "失敗: リクエストボディが上限を超える",
+			requestBody:    `{"name":"` + strings.Repeat("a", presentation.MaxRequestBodyBytes) + `","email":"new@example.com"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"request_too_large","code":"request_too_large","message":"リクエストボディが大きすぎます"}`,
 		},
 		{
 			name:        "失敗: バリデーションエラー (Usecase)",
-			requestBody: `{"id":"validid","name":""}`, // Nameが空
+			requestBody: `{"name":"新規ユーザー","email":"valid@example.com"}`,
 			setupMock: func(mockService *usecase.MockUserService) {
-				invalidUser := &user.User{ID: "validid", Name: ""}
-				validationErr := domainerror.NewValidationError("Name", "名前は必須です")
-				mockService.On("CreateUser", invalidUser).Return(validationErr).Once()
+				newUser := &user.User{Name: "新規ユーザー", Email: mustEmail("valid@example.com")}
+				validationErr := domainerror.NewValidationError("name", user.MsgNameRequired)
+				mockService.On("CreateUser", mock.Anything, newUser).Return(validationErr).Once()
 			},
 			expectedStatus: http.StatusBadRequest, // ミドルウェアが400を返す
-			expectedBody:   `{"error":"invalid_input","message":"Field Name: 名前は必須です"}`,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 名前は必須です","fields":[{"name":"name","reason":"名前は必須です"}]}`,
+		},
+		{
+			name:           "失敗: 複数項目の違反をまとめて返す",
+			requestBody:    `{"id":"clientid","name":"","email":"not-an-email"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field id: IDはサーバーで採番されるため指定できません\nField name: 名前は必須です\nField email: メールアドレスの形式が不正です","fields":[{"name":"id","reason":"IDはサーバーで採番されるため指定できません"},{"name":"name","reason":"名前は必須です"},{"name":"email","reason":"メールアドレスの形式が不正です"}]}`,
 		},
 		{
 			name:        "失敗: 重複エラー (Usecase)",
-			requestBody: `{"id":"duplicateid","name":"重複ユーザー"}`,
+			requestBody: `{"name":"重複ユーザー","email":"dup@example.com"}`,
 			setupMock: func(mockService *usecase.MockUserService) {
-				duplicateUser := &user.User{ID: "duplicateid", Name: "重複ユーザー"}
+				duplicateUser := &user.User{Name: "重複ユーザー", Email: mustEmail("dup@example.com")}
 				duplicateErr := domainerror.NewDuplicateEntryError("duplicateid", "重複ユーザー")
-				mockService.On("CreateUser", duplicateUser).Return(duplicateErr).Once()
+				mockService.On("CreateUser", mock.Anything, duplicateUser).Return(duplicateErr).Once()
+			},
+			expectedStatus: http.StatusConflict,                                                                                   // ミドルウェアが409を返す
+			expectedBody:   `{"error":"duplicate_entry","code":"duplicate_entry","message":"重複エラー: ID=duplicateid, Name=重複ユーザー"}`, // メッセージ調整
+		},
+		{
+			name:           "失敗: メールアドレスの形式が不正",
+			requestBody:    `{"name":"新規ユーザー","email":"not-an-email"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field email: メールアドレスの形式が不正です","fields":[{"name":"email","reason":"メールアドレスの形式が不正です"}]}`,
+		},
+		{
+			name:        "失敗: メールアドレスの重複",
+			requestBody: `{"name":"新規ユーザー","email":"Taken@Example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				newUser := &user.User{Name: "新規ユーザー", Email: mustEmail("taken@example.com")}
+				mockService.On("CreateUser", mock.Anything, newUser).Return(domainerror.NewDuplicateEmailError("taken@example.com")).Once()
 			},
-			expectedStatus: http.StatusConflict,                                                          // ミドルウェアが409を返す
-			expectedBody:   `{"error":"duplicate_entry","message":"重複エラー: ID=duplicateid, Name=重複ユーザー"}`, // メッセージ調整
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"duplicate_email","code":"duplicate_email","message":"メールアドレスは既に使用されています: taken@example.com"}`,
 		},
 		{
 			name:        "失敗: その他の内部エラー (Usecase)",
-			requestBody: `{"id":"internal","name":"内部エラー"}`,
+			requestBody: `{"name":"内部エラー","email":"internal@example.com"}`,
 			setupMock: func(mockService *usecase.MockUserService) {
-				internalUser := &user.User{ID: "internal", Name: "内部エラー"}
-				mockService.On("CreateUser", internalUser).Return(errors.New("予期せぬDBエラー")).Once()
+				internalUser := &user.User{Name: "内部エラー", Email: mustEmail("internal@example.com")}
+				mockService.On("CreateUser", mock.Anything, internalUser).Return(errors.New("予期せぬDBエラー")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError, // ミドルウェアが500を返す
-			expectedBody:   `{"error":"internal_server_error","message":"内部エラーが発生しました"}`,
+			expectedBody:   `{"error":"internal_server_error","code":"internal_server_error","message":"内部エラーが発生しました"}`,
 		},
 	}
 
@@ -217,7 +406,205 @@ func TestCreateUser(t *testing.T) {
 			e := setupTestRouter(handler)
 
 			req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(tt.requestBody))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON) // Content-Typeを設定
+			req.Header.Set(echo.HeaderContentType, cmp.Or(tt.contentType, echo.MIMEApplicationJSON)) // Content-Typeを設定
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedLocation, rec.Header().Get(echo.HeaderLocation))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		requestBody    string
+		setupMock      func(mockService *usecase.MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "成功: ユーザーを更新",
+			userID:      "1",
+			requestBody: `{"name":"更新ユーザー","email":"updated@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				updated := &user.User{ID: "1", Name: "更新ユーザー", Email: mustEmail("updated@example.com")}
+				mockService.On("UpdateUser", mock.Anything, updated).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"更新ユーザー","email":"updated@example.com"}`,
+		},
+		{
+			name:        "失敗: 存在しないユーザーID",
+			userID:      "notfound",
+			requestBody: `{"name":"更新ユーザー","email":"updated@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				notFoundErr := domainerror.NewNotFoundError("User", "notfound")
+				updated := &user.User{ID: "notfound", Name: "更新ユーザー", Email: mustEmail("updated@example.com")}
+				mockService.On("UpdateUser", mock.Anything, updated).Return(notFoundErr).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"not_found","code":"not_found","message":"User (ID: notfound) エンティティが見つかりません"}`,
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ (JSON)",
+			userID:         "1",
+			requestBody:    `{"name":}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(usecase.MockUserService)
+			tt.setupMock(mockService)
+			handler := presentation.NewUserHandler(mockService)
+			e := setupTestRouter(handler)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/"+tt.userID, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	name := "パッチユーザー"
+
+	tests := []struct {
+		name           string
+		userID         string
+		requestBody    string
+		contentType    string
+		setupMock      func(mockService *usecase.MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "成功: nameを部分更新",
+			userID:      "1",
+			requestBody: `{"name":"パッチユーザー"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				patch := &usecase.UserPatch{Name: &name}
+				patched := &user.User{ID: "1", Name: name, Email: mustEmail("test1@example.com")}
+				mockService.On("PatchUser", mock.Anything, "1", patch).Return(patched, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"パッチユーザー","email":"test1@example.com"}`,
+		},
+		{
+			name:        "成功: 空のパッチは何も変更しない",
+			userID:      "1",
+			requestBody: `{}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				current := &user.User{ID: "1", Name: "テストユーザー1", Email: mustEmail("test1@example.com")}
+				mockService.On("PatchUser", mock.Anything, "1", &usecase.UserPatch{}).Return(current, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"テストユーザー1","email":"test1@example.com"}`,
+		},
+		{
+			name:           "失敗: 必須項目をnullで削除",
+			userID:         "1",
+			requestBody:    `{"name":null}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 名前は必須です","fields":[{"name":"name","reason":"名前は必須です"}]}`,
+		},
+		{
+			name:           "失敗: 指定した項目の違反をまとめて返す",
+			userID:         "1",
+			requestBody:    `{"name":"","email":null}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 名前は必須です\nField email: メールアドレスは必須です","fields":[{"name":"name","reason":"名前は必須です"},{"name":"email","reason":"メールアドレスは必須です"}]}`,
+		},
+		{
+			name:           "失敗: Merge Patch以外のContent-Type",
+			userID:         "1",
+			requestBody:    `{"name":"パッチユーザー"}`,
+			contentType:    echo.MIMEApplicationJSON,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"unsupported_media_type","code":"unsupported_media_type","message":"対応していないContent-Typeです"}`,
+		},
+		{
+			name:           "失敗: ボディがnull",
+			userID:         "1",
+			requestBody:    `null`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+		{
+			name:           "失敗: ボディがオブジェクトでない",
+			userID:         "1",
+			requestBody:    ` ["name"]`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+		{
+			name:           "失敗: 文字列以外の値",
+			userID:         "1",
+			requestBody:    `{"name":123}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field name: 型が不正です","fields":[{"name":"name","reason":"型が不正です"}]}`,
+		},
+		{
+			name:           "失敗: 未知のフィールド",
+			userID:         "1",
+			requestBody:    `{"nickname":"パッチ"}`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid_input","code":"invalid_input","message":"Field nickname: 未知の項目です","fields":[{"name":"nickname","reason":"未知の項目です"}]}`,
+		},
+		{
+			name:           "失敗: オブジェクト以外のパッチ",
+			userID:         "1",
+			requestBody:    `["name"]`,
+			setupMock:      func(_ *usecase.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"bad_request","code":"bad_request","message":"不正なリクエストです"}`,
+		},
+		{
+			name:        "失敗: 存在しないユーザーID",
+			userID:      "notfound",
+			requestBody: `{"name":"パッチユーザー"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				patch := &usecase.UserPatch{Name: &name}
+				notFoundErr := domainerror.NewNotFoundError("User", "notfound")
+				mockService.On("PatchUser", mock.Anything, "notfound", patch).Return(nil, notFoundErr).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"not_found","code":"not_found","message":"User (ID: notfound) エンティティが見つかりません"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(usecase.MockUserService)
+			tt.setupMock(mockService)
+			handler := presentation.NewUserHandler(mockService)
+			e := setupTestRouter(handler)
+
+			req := httptest.NewRequest(http.MethodPatch, "/users/"+tt.userID, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, cmp.Or(tt.contentType, presentation.MIMEApplicationMergePatchJSON))
 			rec := httptest.NewRecorder()
 			e.ServeHTTP(rec, req)
 
@@ -227,3 +614,53 @@ func TestCreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		setupMock      func(mockService *usecase.MockUserService, id string)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "成功: ユーザーを削除",
+			userID: "1",
+			setupMock: func(mockService *usecase.MockUserService, id string) {
+				mockService.On("DeleteUser", mock.Anything, id).Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   ``,
+		},
+		{
+			name:   "失敗: 存在しないユーザーID",
+			userID: "notfound",
+			setupMock: func(mockService *usecase.MockUserService, id string) {
+				mockService.On("DeleteUser", mock.Anything, id).Return(domainerror.NewNotFoundError("User", id)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"not_found","code":"not_found","message":"User (ID: notfound) エンティティが見つかりません"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(usecase.MockUserService)
+			tt.setupMock(mockService, tt.userID)
+			handler := presentation.NewUserHandler(mockService)
+			e := setupTestRouter(handler)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+tt.userID, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/health"
+)
+
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{registry: registry}
+}
+
+// Liveness はプロセスが応答可能かだけを返します
+// 依存先の障害で再起動が繰り返されないよう、依存先のチェックは行いません
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusUp})
+}
+
+// Readiness は依存先のチェック結果に応じて200または503を返します
+// ?verbose=true の場合は各チェックの詳細を含めます
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	report := h.registry.Check(c.Request().Context())
+
+	statusCode := http.StatusOK
+	if report.Status != health.StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	if c.QueryParam("verbose") != "true" {
+		report.Checks = nil
+	}
+	return c.JSON(statusCode, report)
+}
+
+func (h *HealthHandler) SetupHealthRoutes(g *echo.Group) {
+	g.GET("/healthz", h.Liveness)
+	g.GET("/readyz", h.Readiness)
+}
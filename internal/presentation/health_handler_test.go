@@ -0,0 +1,89 @@
+package presentation_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nansystem/go-ddd/internal/health"
+	"github.com/nansystem/go-ddd/internal/presentation"
+)
+
+func TestHealthHandler(t *testing.T) {
+	up := health.CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return map[string]any{"in_use": 0}, nil
+	})
+	down := health.CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	tests := []struct {
+		name           string
+		checkers       map[string]health.Checker
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "成功: livenessは依存先に関わらず200",
+			checkers:       map[string]health.Checker{"mysql": down},
+			target:         "/healthz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"up"}`,
+		},
+		{
+			name:           "成功: すべての依存先が正常",
+			checkers:       map[string]health.Checker{"mysql": up},
+			target:         "/readyz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"up"}`,
+		},
+		{
+			name:           "失敗: 依存先が異常",
+			checkers:       map[string]health.Checker{"mysql": up, "github": down},
+			target:         "/readyz",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"down"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := health.NewRegistry(0)
+			for name, checker := range tt.checkers {
+				registry.Register(name, checker, time.Second)
+			}
+			e := echo.New()
+			presentation.NewHealthHandler(registry).SetupHealthRoutes(e.Group(""))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHealthHandler_Verbose(t *testing.T) {
+	registry := health.NewRegistry(0)
+	registry.Register("github", health.CheckerFunc(func(_ context.Context) (map[string]any, error) {
+		return nil, errors.New("401 Unauthorized")
+	}), time.Second)
+	e := echo.New()
+	presentation.NewHealthHandler(registry).SetupHealthRoutes(e.Group(""))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz?verbose=true", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"github":{"status":"down","error":"401 Unauthorized"`)
+}
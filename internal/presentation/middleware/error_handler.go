@@ -1,24 +1,66 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/i18n"
+	"github.com/nansystem/go-ddd/internal/logging"
+	"github.com/nansystem/go-ddd/internal/requestid"
 )
 
+// StatusClientClosedRequest はクライアントが応答を待たずに切断したことを表す非標準のステータスです (nginx由来)
+const StatusClientClosedRequest = 499
+
+// handledErrorKey はErrorHandlerMiddlewareがレスポンスに変換したエラーをecho.Contextに格納するキーです
+// 外側のミドルウェアはエラーを受け取らず、このエラーと確定したステータスを記録します
+const handledErrorKey = "handled_error"
+
 // ErrorResponse はエラーレスポンスの形式を定義します
+// RFC 9457形式を要求されなかった場合に使う従来の形式です
 type ErrorResponse struct {
+	// Error はエラーコード (ErrorCatalogのキー) です
 	Error   string `json:"error"`
 	Message string `json:"message,omitempty"`
-	Code    string `json:"code,omitempty"`
+	// Code はErrorと同じエラーコードです
+	// RFC 9457形式のcodeと同じ名前で参照できるよう、どちらの形式にも含めます
+	Code string `json:"code,omitempty"`
+	// RequestID はログと照合するためのリクエストIDです
+	RequestID string `json:"request_id,omitempty"`
+	// Fields は検証に失敗した入力項目です (RFC 9457形式のinvalid-paramsと同じ内容)
+	Fields []InvalidParam `json:"fields,omitempty"`
 }
 
+// ErrorHandlerConfig はErrorHandlerMiddlewareの設定です
+type ErrorHandlerConfig struct {
+	// ProblemDetails がtrueの場合、Acceptヘッダーによらず常にRFC 9457形式 (application/problem+json) で返します
+	// falseの場合もAcceptヘッダーで application/problem+json を要求されればRFC 9457形式で返します
+	ProblemDetails bool
+	// Catalog はメッセージの翻訳に使うカタログです (nilの場合は埋め込みのカタログ)
+	Catalog *i18n.Catalog
+}
+
+// DefaultErrorHandlerConfig は既定の設定です (要求された場合のみRFC 9457形式)
+var DefaultErrorHandlerConfig = ErrorHandlerConfig{}
+
 // ErrorHandlerMiddleware はAPIエラーハンドリングのミドルウェアです
 func ErrorHandlerMiddleware() echo.MiddlewareFunc {
+	return ErrorHandlerMiddlewareWithConfig(DefaultErrorHandlerConfig)
+}
+
+// ErrorHandlerMiddlewareWithConfig は設定を指定してAPIエラーハンドリングのミドルウェアを作成します
+func ErrorHandlerMiddlewareWithConfig(config ErrorHandlerConfig) echo.MiddlewareFunc {
+	catalog := config.Catalog
+	if catalog == nil {
+		catalog = i18n.Default()
+	}
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// 次のハンドラを実行
@@ -29,98 +71,177 @@ func ErrorHandlerMiddleware() echo.MiddlewareFunc {
 				return nil
 			}
 
-			// エラーの種類に応じてHTTPステータスとメッセージを設定
-			var statusCode int
-			var response ErrorResponse
-
-			// 独自のエラータイプを判別
-			var notFoundErr *domainerror.NotFoundError
-			var duplicateErr *domainerror.DuplicateEntryError
-			var validationErr *domainerror.ValidationError
-			var httpErr *echo.HTTPError
-
-			// エラータイプに基づいてレスポンスを構築
-			switch {
-			case errors.Is(err, domainerror.ErrNotFound) || errors.As(err, &notFoundErr):
-				statusCode = http.StatusNotFound
-				response.Error = "not_found"
-				response.Message = err.Error()
-
-			case errors.Is(err, domainerror.ErrDuplicated) || errors.As(err, &duplicateErr):
-				statusCode = http.StatusConflict
-				response.Error = "duplicate_entry"
-				response.Message = err.Error()
-
-			case errors.Is(err, domainerror.ErrInvalidInput) || errors.As(err, &validationErr):
-				statusCode = http.StatusBadRequest
-				response.Error = "invalid_input"
-				response.Message = err.Error()
-
-			case errors.Is(err, domainerror.ErrUnauthorized):
-				statusCode = http.StatusUnauthorized
-				response.Error = "unauthorized"
-				response.Message = err.Error()
-
-			// データベース関連エラーは内部エラーとして扱う
-			case errors.Is(err, domainerror.ErrDatabase) ||
-				errors.Is(err, domainerror.ErrConnection) ||
-				errors.Is(err, domainerror.ErrTransaction) ||
-				errors.Is(err, domainerror.ErrQuery):
-				statusCode = http.StatusInternalServerError
-				response.Error = "internal_server_error"
-				response.Message = "内部エラーが発生しました"
-
-				// ログにエラー詳細を記録（本番環境ではクライアントに詳細を返さない）
-				c.Logger().Error(err)
-
-			case errors.As(err, &httpErr):
-				statusCode = httpErr.Code
-				if httpErr.Message != nil {
-					if msgStr, ok := httpErr.Message.(string); ok {
-						response.Message = msgStr
-					} else if msgErr, ok := httpErr.Message.(error); ok {
-						response.Message = msgErr.Error()
-					} else {
-						response.Message = fmt.Sprintf("%v", httpErr.Message)
-					}
-				} else {
-					response.Message = http.StatusText(statusCode)
-				}
-
-				switch statusCode {
-				case http.StatusBadRequest:
-					response.Error = "bad_request"
-					response.Message = "不正なリクエストです"
-				case http.StatusNotFound:
-					response.Error = "not_found"
-					response.Message = "リソースが見つかりません"
-				case http.StatusMethodNotAllowed:
-					response.Error = "method_not_allowed"
-					response.Message = "許可されていないメソッドです"
-				default:
-					response.Error = "http_error"
-				}
-
-			default:
-				// その他のエラー
-				statusCode = http.StatusInternalServerError
-				response.Error = "internal_server_error"
-				response.Message = "内部エラーが発生しました"
-
-				// 開発環境では元のエラーメッセージも含める
-				if c.Echo().Debug {
-					response.Message = err.Error()
-				}
-
-				// ログにエラー詳細を記録
-				c.Logger().Error(err)
+			// メッセージはAccept-Languageで選んだ言語で返す
+			l := localizer{catalog: catalog, lang: catalog.Negotiate(c.Request().Header.Get("Accept-Language"))}
+			statusCode, code, message := classifyError(c, err, l)
+
+			// サーバー側の問題はラップされたエラーの連鎖と分類をすべて記録する
+			if statusCode >= http.StatusInternalServerError {
+				ctx := c.Request().Context()
+				logging.FromContext(ctx).LogAttrs(ctx, slog.LevelError, "リクエストの処理に失敗しました",
+					slog.String("error", err.Error()),
+					slog.String("error_code", code),
+					slog.Any("error_kinds", errorKinds(err)),
+					slog.Any("error_chain", errorChain(err)),
+				)
+			}
+
+			// MetricsMiddlewareが分類ごとに数え、ログとトレースに元のエラーを記録できるよう格納する
+			c.Set(errorKindKey, code)
+			c.Set(handledErrorKey, err)
+
+			if c.Response().Committed {
+				return nil
+			}
+			requestID := requestid.FromContext(c.Request().Context())
+			c.Response().Header().Set("Content-Language", l.lang)
+			if config.ProblemDetails || acceptsProblem(c.Request()) {
+				problem := newProblem(statusCode, code, message, err, l)
+				problem.Instance = c.Request().URL.Path
+				problem.RequestID = requestID
+				c.Response().Header().Set(echo.HeaderContentType, ProblemContentType)
+				return c.JSON(statusCode, problem)
 			}
 
 			// JSONレスポンスを返す
-			if !c.Response().Committed {
-				return c.JSON(statusCode, response)
+			return c.JSON(statusCode, ErrorResponse{
+				Error:     code,
+				Message:   message,
+				Code:      code,
+				RequestID: requestID,
+				Fields:    invalidParams(code, err, l),
+			})
+		}
+	}
+}
+
+// classifyError はエラーの種類に応じてHTTPステータス、エラーコード、メッセージを決定します
+func classifyError(c echo.Context, err error, l localizer) (statusCode int, code, message string) {
+	// 独自のエラータイプを判別
+	var notFoundErr *domainerror.NotFoundError
+	var duplicateErr *domainerror.DuplicateEntryError
+	var duplicateEmailErr *domainerror.DuplicateEmailError
+	var validationErr *domainerror.ValidationError
+	var httpErr *echo.HTTPError
+
+	// エラータイプに基づいてレスポンスを構築
+	switch {
+	case errors.Is(err, domainerror.ErrNotFound) || errors.As(err, &notFoundErr):
+		return http.StatusNotFound, CodeNotFound, l.errorMessage(err, CodeNotFound)
+
+	// メールアドレスの重複はクライアントが区別できるよう個別のエラーにする
+	case errors.As(err, &duplicateEmailErr):
+		return http.StatusConflict, CodeDuplicateEmail, l.errorMessage(err, CodeDuplicateEmail)
+
+	case errors.Is(err, domainerror.ErrDuplicated) || errors.As(err, &duplicateErr):
+		return http.StatusConflict, CodeDuplicateEntry, l.errorMessage(err, CodeDuplicateEntry)
+
+	case errors.Is(err, domainerror.ErrInvalidInput) || errors.As(err, &validationErr):
+		return http.StatusBadRequest, CodeInvalidInput, l.validationMessage(err)
+
+	case errors.Is(err, domainerror.ErrUnauthorized):
+		return http.StatusUnauthorized, CodeUnauthorized, l.errorMessage(err, CodeUnauthorized)
+
+	// 期限切れ・キャンセルはDBエラーより先に判定する
+	case errors.Is(err, domainerror.ErrTimeout) || errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, CodeTimeout, l.codeMessage(CodeTimeout)
+
+	case errors.Is(err, domainerror.ErrCanceled) || errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest, CodeClientClosedRequest, l.codeMessage(CodeClientClosedRequest)
+
+	// データベース関連エラーは内部エラーとして扱う
+	case errors.Is(err, domainerror.ErrDatabase) ||
+		errors.Is(err, domainerror.ErrConnection) ||
+		errors.Is(err, domainerror.ErrTransaction) ||
+		errors.Is(err, domainerror.ErrQuery):
+		// 本番環境ではクライアントに詳細を返さず、ログにのみ記録する
+		return http.StatusInternalServerError, CodeInternalServerError, l.codeMessage(CodeInternalServerError)
+
+	case errors.As(err, &httpErr):
+		switch httpErr.Code {
+		case http.StatusBadRequest:
+			return httpErr.Code, CodeBadRequest, l.codeMessage(CodeBadRequest)
+		case http.StatusNotFound:
+			return httpErr.Code, CodeNotFound, l.codeMessage(CodeNotFound)
+		case http.StatusMethodNotAllowed:
+			return httpErr.Code, CodeMethodNotAllowed, l.codeMessage(CodeMethodNotAllowed)
+		case http.StatusRequestEntityTooLarge:
+			return httpErr.Code, CodeRequestTooLarge, l.codeMessage(CodeRequestTooLarge)
+		case http.StatusUnsupportedMediaType:
+			return httpErr.Code, CodeUnsupportedMediaType, l.codeMessage(CodeUnsupportedMediaType)
+		}
+
+		message := http.StatusText(httpErr.Code)
+		if httpErr.Message != nil {
+			if msgStr, ok := httpErr.Message.(string); ok {
+				message = msgStr
+			} else if msgErr, ok := httpErr.Message.(error); ok {
+				message = msgErr.Error()
+			} else {
+				message = fmt.Sprintf("%v", httpErr.Message)
+			}
+		}
+		return httpErr.Code, CodeHTTPError, message
+
+	default:
+		// その他のエラー
+		message = l.codeMessage(CodeInternalServerError)
+		// 開発環境では元のエラーメッセージも含める
+		if c.Echo().Debug {
+			message = err.Error()
+		}
+		return http.StatusInternalServerError, CodeInternalServerError, message
+	}
+}
+
+// domainKinds はログに記録するドメインエラーの分類です
+var domainKinds = []struct {
+	name string
+	err  error
+}{
+	{"not_found", domainerror.ErrNotFound},
+	{"invalid_input", domainerror.ErrInvalidInput},
+	{"duplicated", domainerror.ErrDuplicated},
+	{"unauthorized", domainerror.ErrUnauthorized},
+	{"internal", domainerror.ErrInternal},
+	{"timeout", domainerror.ErrTimeout},
+	{"canceled", domainerror.ErrCanceled},
+	{"database", domainerror.ErrDatabase},
+	{"connection", domainerror.ErrConnection},
+	{"transaction", domainerror.ErrTransaction},
+	{"query", domainerror.ErrQuery},
+}
+
+// errorKinds はエラーが該当するドメインエラーの分類をすべて返します
+func errorKinds(err error) []string {
+	kinds := []string{}
+	for _, k := range domainKinds {
+		if errors.Is(err, k.err) {
+			kinds = append(kinds, k.name)
+		}
+	}
+	return kinds
+}
+
+// errorChain はラップされたエラーを外側から順にたどり、各エラーの型とメッセージを返します
+// errors.Joinなどで複数のエラーをラップしている場合は深さ優先でたどります
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		chain = append(chain, fmt.Sprintf("%T: %s", err, err))
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
 			}
-			return nil
 		}
 	}
+	walk(err)
+	return chain
 }
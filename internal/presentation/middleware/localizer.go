@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/i18n"
+)
+
+// localizer はネゴシエーションした言語でエラーメッセージを組み立てます
+type localizer struct {
+	catalog *i18n.Catalog
+	lang    string
+}
+
+// codeMessage はエラーコードごとの汎用メッセージを返します
+func (l localizer) codeMessage(code string) string {
+	return l.catalog.Translate(l.lang, "error.code."+code, nil)
+}
+
+// problemTitle はRFC 9457形式のtitleに使うエラーコードごとの要約を返します
+func (l localizer) problemTitle(code string) string {
+	return l.catalog.Translate(l.lang, "problem.title."+code, nil)
+}
+
+// message はメッセージキーを持つエラーを翻訳します
+func (l localizer) message(e domainerror.Localizable) string {
+	return l.catalog.Translate(l.lang, e.MessageKey(), e.MessageParams())
+}
+
+// errorMessage はエラーがメッセージキーを持っていれば翻訳し、なければエラーコードの汎用メッセージを返します
+func (l localizer) errorMessage(err error, code string) string {
+	var localizable domainerror.Localizable
+	if errors.As(err, &localizable) {
+		return l.message(localizable)
+	}
+	return l.codeMessage(code)
+}
+
+// validationMessage は検証エラーを項目ごとに1行ずつ翻訳します
+func (l localizer) validationMessage(err error) string {
+	verrs := validationErrors(err)
+	if len(verrs) == 0 {
+		return l.codeMessage(CodeInvalidInput)
+	}
+	lines := make([]string, len(verrs))
+	for i, v := range verrs {
+		lines[i] = l.catalog.Translate(l.lang, domainerror.MsgValidation, map[string]any{
+			"field":  v.Field,
+			"reason": l.message(v),
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validationErrors はエラーに含まれるValidationErrorをすべて取り出します
+// errors.Joinなどで複数の検証エラーをまとめている場合も深さ優先でたどります
+func validationErrors(err error) []*domainerror.ValidationError {
+	var verrs []*domainerror.ValidationError
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if v, ok := err.(*domainerror.ValidationError); ok {
+			verrs = append(verrs, v)
+			return
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	return verrs
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/metrics"
+)
+
+// errorKindKey はErrorHandlerMiddlewareが分類したエラーの種類をecho.Contextに格納するキーです
+const errorKindKey = "error_kind"
+
+// unmatchedRoute はどのルートにも一致しなかったリクエストのラベルです
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware はリクエスト数・エラー・処理時間 (RED) と、分類されたエラーの数を記録します
+// 最終的なステータスとエラーの分類を記録するため、ErrorHandlerMiddlewareより外側で使います
+func MetricsMiddleware(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			// エラーはErrorHandlerMiddlewareがレスポンスに変換済みのため、確定したステータスを記録する
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			m.ObserveHTTPRequest(c.Request().Method, route, c.Response().Status, time.Since(start))
+			if kind, ok := c.Get(errorKindKey).(string); ok {
+				m.CountDomainError(kind)
+			}
+			return err
+		}
+	}
+}
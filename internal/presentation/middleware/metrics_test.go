@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/metrics"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		handlerErr error
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:       "ルートの定義とステータスの区分で数える",
+			path:       "/users/u1",
+			expected:   []string{`go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="2xx"} 1`},
+			unexpected: []string{"go_ddd_domain_errors_total{"},
+		},
+		{
+			name:       "ドメインエラーは分類ごとに数える",
+			path:       "/users/u1",
+			handlerErr: domainerror.NewNotFoundError("User", "u1"),
+			expected: []string{
+				`go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="4xx"} 1`,
+				`go_ddd_domain_errors_total{kind="not_found"} 1`,
+			},
+		},
+		{
+			name:       "DBエラーは5xxとして数える",
+			path:       "/users/u1",
+			handlerErr: domainerror.NewDatabaseError(domainerror.ErrConnection, "select", "users", errors.New("boom")),
+			expected: []string{
+				`go_ddd_http_requests_total{method="GET",route="/users/:id",status_class="5xx"} 1`,
+				`go_ddd_domain_errors_total{kind="internal_server_error"} 1`,
+			},
+		},
+		{
+			name:     "一致するルートがなければunmatched",
+			path:     "/unknown",
+			expected: []string{`go_ddd_http_requests_total{method="GET",route="unmatched",status_class="4xx"} 1`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := metrics.New()
+			e := echo.New()
+			e.Use(middleware.MetricsMiddleware(m))
+			e.Use(middleware.ErrorHandlerMiddleware())
+			e.GET("/users/:id", func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			e.GET("/metrics", echo.WrapHandler(m.Handler()))
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			body, err := io.ReadAll(rec.Body)
+			require.NoError(t, err)
+			for _, s := range tt.expected {
+				assert.Contains(t, string(body), s)
+			}
+			for _, s := range tt.unexpected {
+				assert.NotContains(t, string(body), s)
+			}
+		})
+	}
+}
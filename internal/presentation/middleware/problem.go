@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType はRFC 9457のエラーレスポンスのContent-Typeです
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase はエラーの種類を表すURIの接頭辞です
+const problemTypeBase = "urn:go-ddd:problem:"
+
+// エラーコード
+// クライアントが分岐に使うため、一度公開した値は変更しません
+const (
+	CodeNotFound             = "not_found"
+	CodeDuplicateEmail       = "duplicate_email"
+	CodeDuplicateEntry       = "duplicate_entry"
+	CodeInvalidInput         = "invalid_input"
+	CodeUnauthorized         = "unauthorized"
+	CodeTimeout              = "timeout"
+	CodeClientClosedRequest  = "client_closed_request"
+	CodeInternalServerError  = "internal_server_error"
+	CodeBadRequest           = "bad_request"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeHTTPError            = "http_error"
+)
+
+// ErrorCatalogEntry はエラーコードごとのRFC 9457の種類です
+// タイトルはメッセージカタログの problem.title.{コード} をネゴシエーションした言語に翻訳して使います
+type ErrorCatalogEntry struct {
+	// Type はエラーの種類を識別するURIです
+	Type string
+	// Status はこのエラーコードで返すHTTPステータスです (APIドキュメントの生成に使います)
+	Status int
+}
+
+// ErrorCatalog はエラーコードとRFC 9457の種類の対応表です
+var ErrorCatalog = map[string]ErrorCatalogEntry{
+	CodeNotFound:             {Type: problemTypeBase + CodeNotFound, Status: http.StatusNotFound},
+	CodeDuplicateEmail:       {Type: problemTypeBase + CodeDuplicateEmail, Status: http.StatusConflict},
+	CodeDuplicateEntry:       {Type: problemTypeBase + CodeDuplicateEntry, Status: http.StatusConflict},
+	CodeInvalidInput:         {Type: problemTypeBase + CodeInvalidInput, Status: http.StatusBadRequest},
+	CodeUnauthorized:         {Type: problemTypeBase + CodeUnauthorized, Status: http.StatusUnauthorized},
+	CodeTimeout:              {Type: problemTypeBase + CodeTimeout, Status: http.StatusGatewayTimeout},
+	CodeClientClosedRequest:  {Type: problemTypeBase + CodeClientClosedRequest, Status: StatusClientClosedRequest},
+	CodeInternalServerError:  {Type: problemTypeBase + CodeInternalServerError, Status: http.StatusInternalServerError},
+	CodeBadRequest:           {Type: problemTypeBase + CodeBadRequest, Status: http.StatusBadRequest},
+	CodeMethodNotAllowed:     {Type: problemTypeBase + CodeMethodNotAllowed, Status: http.StatusMethodNotAllowed},
+	CodeRequestTooLarge:      {Type: problemTypeBase + CodeRequestTooLarge, Status: http.StatusRequestEntityTooLarge},
+	CodeUnsupportedMediaType: {Type: problemTypeBase + CodeUnsupportedMediaType, Status: http.StatusUnsupportedMediaType},
+	// 個別に分類していないHTTPエラーは種類を特定しない (titleはステータスの説明にする)
+	CodeHTTPError: {Type: "about:blank"},
+}
+
+// Problem はRFC 9457のエラーレスポンスです
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// 以下は拡張メンバーです
+	// Code はErrorCatalogのキーとなるエラーコードです
+	Code          string         `json:"code"`
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam は検証に失敗した入力項目です
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// newProblem はエラーの分類結果からProblemを作成します
+func newProblem(status int, code, detail string, err error, l localizer) Problem {
+	entry, ok := ErrorCatalog[code]
+	if !ok {
+		entry = ErrorCatalog[CodeHTTPError]
+	}
+	title := http.StatusText(status)
+	if ok && code != CodeHTTPError {
+		title = l.problemTitle(code)
+	}
+	return Problem{
+		Type:          entry.Type,
+		Title:         title,
+		Status:        status,
+		Detail:        detail,
+		Code:          code,
+		InvalidParams: invalidParams(code, err, l),
+	}
+}
+
+// invalidParams は入力値エラーに含まれる検証エラーを項目ごとに翻訳して返します
+func invalidParams(code string, err error, l localizer) []InvalidParam {
+	if code != CodeInvalidInput {
+		return nil
+	}
+	var params []InvalidParam
+	for _, v := range validationErrors(err) {
+		params = append(params, InvalidParam{Name: v.Field, Reason: l.message(v)})
+	}
+	return params
+}
+
+// acceptsProblem はAcceptヘッダーでRFC 9457形式が要求されているかを返します
+// 品質値 (q=0) で明示的に拒否されている場合は要求されていないものとします
+func acceptsProblem(req *http.Request) bool {
+	for _, accept := range req.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != ProblemContentType {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				weight, err := strconv.ParseFloat(q, 64)
+				return err == nil && weight > 0
+			}
+			return true
+		}
+	}
+	return false
+}
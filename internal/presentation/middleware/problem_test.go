@@ -0,0 +1,305 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/i18n"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+func TestErrorHandlerMiddleware_ProblemDetails(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        middleware.ErrorHandlerConfig
+		accept        string
+		handlerErr    error
+		expectProblem bool
+		expected      middleware.Problem
+	}{
+		{
+			name:       "Acceptで要求されなければ従来の形式",
+			accept:     "application/json",
+			handlerErr: domainerror.NewNotFoundError("User", "u1"),
+		},
+		{
+			name:          "Acceptで要求されればRFC 9457形式",
+			accept:        "application/json, application/problem+json",
+			handlerErr:    domainerror.NewNotFoundError("User", "u1"),
+			expectProblem: true,
+			expected: middleware.Problem{
+				Type:     "urn:go-ddd:problem:not_found",
+				Title:    "リソースが見つかりません",
+				Status:   http.StatusNotFound,
+				Detail:   "User (ID: u1) エンティティが見つかりません",
+				Instance: "/users/u1",
+				Code:     middleware.CodeNotFound,
+			},
+		},
+		{
+			name:       "q=0で拒否されていれば従来の形式",
+			accept:     "application/problem+json;q=0",
+			handlerErr: domainerror.NewNotFoundError("User", "u1"),
+		},
+		{
+			name:          "設定で有効にすればAcceptによらずRFC 9457形式",
+			config:        middleware.ErrorHandlerConfig{ProblemDetails: true},
+			handlerErr:    domainerror.NewDatabaseError(domainerror.ErrConnection, "select", "users", errors.New("connection refused")),
+			expectProblem: true,
+			expected: middleware.Problem{
+				Type:     "urn:go-ddd:problem:internal_server_error",
+				Title:    "内部エラー",
+				Status:   http.StatusInternalServerError,
+				Detail:   "内部エラーが発生しました",
+				Instance: "/users/u1",
+				Code:     middleware.CodeInternalServerError,
+			},
+		},
+		{
+			name:   "検証エラーはinvalid-paramsに項目ごとに含める",
+			config: middleware.ErrorHandlerConfig{ProblemDetails: true},
+			handlerErr: errors.Join(
+				domainerror.NewValidationError("name", user.MsgNameRequired),
+				domainerror.NewValidationError("email", user.MsgEmailInvalid),
+			),
+			expectProblem: true,
+			expected: middleware.Problem{
+				Type:     "urn:go-ddd:problem:invalid_input",
+				Title:    "入力値が不正です",
+				Status:   http.StatusBadRequest,
+				Detail:   "Field name: 名前は必須です\nField email: メールアドレスの形式が不正です",
+				Instance: "/users/u1",
+				Code:     middleware.CodeInvalidInput,
+				InvalidParams: []middleware.InvalidParam{
+					{Name: "name", Reason: "名前は必須です"},
+					{Name: "email", Reason: "メールアドレスの形式が不正です"},
+				},
+			},
+		},
+		{
+			name:          "分類していないHTTPエラーはabout:blank",
+			config:        middleware.ErrorHandlerConfig{ProblemDetails: true},
+			handlerErr:    echo.NewHTTPError(http.StatusTooManyRequests),
+			expectProblem: true,
+			expected: middleware.Problem{
+				Type:     "about:blank",
+				Title:    "Too Many Requests",
+				Status:   http.StatusTooManyRequests,
+				Detail:   "Too Many Requests",
+				Instance: "/users/u1",
+				Code:     middleware.CodeHTTPError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(middleware.RequestIDMiddleware())
+			e.Use(middleware.ErrorHandlerMiddlewareWithConfig(tt.config))
+			e.GET("/users/:id", func(_ echo.Context) error {
+				return tt.handlerErr
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/u1", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if !tt.expectProblem {
+				assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+				var response middleware.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.NotEmpty(t, response.Error)
+				return
+			}
+
+			assert.Equal(t, middleware.ProblemContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			var problem middleware.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, rec.Header().Get(requestid.Header), problem.RequestID)
+			problem.RequestID = ""
+			assert.Equal(t, tt.expected, problem)
+			if problem.Code != middleware.CodeHTTPError {
+				assert.Equal(t, rec.Code, middleware.ErrorCatalog[problem.Code].Status)
+			}
+		})
+	}
+}
+
+func TestErrorCatalog(t *testing.T) {
+	codes := []string{
+		middleware.CodeNotFound,
+		middleware.CodeDuplicateEmail,
+		middleware.CodeDuplicateEntry,
+		middleware.CodeInvalidInput,
+		middleware.CodeUnauthorized,
+		middleware.CodeTimeout,
+		middleware.CodeClientClosedRequest,
+		middleware.CodeInternalServerError,
+		middleware.CodeBadRequest,
+		middleware.CodeMethodNotAllowed,
+		middleware.CodeRequestTooLarge,
+		middleware.CodeUnsupportedMediaType,
+		middleware.CodeHTTPError,
+	}
+	// すべてのエラーコードに種類が定義されている
+	for _, code := range codes {
+		entry, ok := middleware.ErrorCatalog[code]
+		if assert.True(t, ok, code) {
+			assert.NotEmpty(t, entry.Type, code)
+			// 分類していないHTTPエラー以外はステータスが固定
+			assert.Equal(t, code != middleware.CodeHTTPError, entry.Status != 0, code)
+		}
+		// 分類していないHTTPエラー以外はすべての言語にtitleの翻訳がある
+		if code == middleware.CodeHTTPError {
+			continue
+		}
+		for _, lang := range i18n.Default().Languages() {
+			assert.Contains(t, i18n.Default().Keys(lang), "problem.title."+code, lang)
+		}
+	}
+	assert.Len(t, middleware.ErrorCatalog, len(codes))
+}
+
+func TestErrorHandlerMiddleware_AcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		problem         bool
+		handlerErr      error
+		expectedLang    string
+		expectedMessage string
+		expectedTitle   string
+		expectedReasons []string
+	}{
+		{
+			name:            "指定がなければ日本語",
+			handlerErr:      domainerror.NewNotFoundError("User", "u1"),
+			expectedLang:    "ja",
+			expectedMessage: "User (ID: u1) エンティティが見つかりません",
+		},
+		{
+			name:            "英語のドメインエラー",
+			acceptLanguage:  "en-US,en;q=0.9",
+			handlerErr:      domainerror.NewNotFoundError("User", "u1"),
+			expectedLang:    "en",
+			expectedMessage: "User (ID: u1) not found",
+		},
+		{
+			name:            "英語の内部エラー",
+			acceptLanguage:  "en",
+			handlerErr:      domainerror.ErrDatabase,
+			expectedLang:    "en",
+			expectedMessage: "An internal error occurred",
+		},
+		{
+			name:            "パラメータ付きの検証エラー",
+			acceptLanguage:  "en",
+			handlerErr:      domainerror.NewValidationErrorWithParams("limit", user.MsgLimitOutOfRange, map[string]any{"min": 1, "max": 100}),
+			expectedLang:    "en",
+			expectedMessage: "Field limit: must be between 1 and 100",
+			expectedReasons: []string{"must be between 1 and 100"},
+		},
+		{
+			name:            "従来の形式でも項目ごとの理由を翻訳する",
+			acceptLanguage:  "en",
+			handlerErr:      errors.Join(domainerror.NewValidationError("name", user.MsgNameRequired), domainerror.NewValidationError("email", user.MsgEmailInvalid)),
+			expectedLang:    "en",
+			expectedMessage: "Field name: name is required\nField email: email address is malformed",
+			expectedReasons: []string{"name is required", "email address is malformed"},
+		},
+		{
+			name:            "invalid-paramsの理由も翻訳する",
+			acceptLanguage:  "en",
+			problem:         true,
+			handlerErr:      errors.Join(domainerror.NewValidationError("name", user.MsgNameRequired), domainerror.NewValidationError("email", user.MsgEmailInvalid)),
+			expectedLang:    "en",
+			expectedMessage: "Field name: name is required\nField email: email address is malformed",
+			expectedTitle:   "Invalid input",
+			expectedReasons: []string{"name is required", "email address is malformed"},
+		},
+		{
+			name:            "未対応の言語は日本語",
+			acceptLanguage:  "fr",
+			handlerErr:      context.DeadlineExceeded,
+			expectedLang:    "ja",
+			expectedMessage: "処理がタイムアウトしました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(middleware.ErrorHandlerMiddlewareWithConfig(middleware.ErrorHandlerConfig{ProblemDetails: tt.problem}))
+			e.GET("/users/:id", func(_ echo.Context) error {
+				return tt.handlerErr
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/u1", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedLang, rec.Header().Get("Content-Language"))
+			if !tt.problem {
+				var response middleware.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response.Message)
+				var reasons []string
+				for _, f := range response.Fields {
+					reasons = append(reasons, f.Reason)
+				}
+				assert.Equal(t, tt.expectedReasons, reasons)
+				return
+			}
+
+			var problem middleware.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedMessage, problem.Detail)
+			assert.Equal(t, tt.expectedTitle, problem.Title)
+			var reasons []string
+			for _, p := range problem.InvalidParams {
+				reasons = append(reasons, p.Reason)
+			}
+			assert.Equal(t, tt.expectedReasons, reasons)
+		})
+	}
+}
+
+func TestErrorHandlerMiddleware_ValidationErrors(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.ErrorHandlerMiddlewareWithConfig(middleware.ErrorHandlerConfig{ProblemDetails: true}))
+	e.POST("/users", func(_ echo.Context) error {
+		return user.CreateCommand{ID: "x", Name: "", Email: "invalid"}.Validate()
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var problem middleware.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, middleware.CodeInvalidInput, problem.Code)
+	assert.Equal(t, []middleware.InvalidParam{
+		{Name: "id", Reason: "IDはサーバーで採番されるため指定できません"},
+		{Name: "name", Reason: "名前は必須です"},
+		{Name: "email", Reason: "メールアドレスの形式が不正です"},
+	}, problem.InvalidParams)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+// RequestIDMiddleware はX-Request-IDヘッダーのリクエストIDを受け付け、なければ生成します
+// リクエストIDはコンテキストに格納し、レスポンスヘッダーにも設定します
+// 形式が不正なIDはログやSQLに埋め込めないため、新しいIDに置き換えます
+// 他のミドルウェアがIDを使えるよう、最も外側で使います
+func RequestIDMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+
+			c.SetRequest(req.WithContext(requestid.WithID(req.Context(), id)))
+			c.Response().Header().Set(requestid.Header, id)
+			return next(c)
+		}
+	}
+}
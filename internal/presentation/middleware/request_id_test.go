@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		expectSame bool
+	}{
+		{name: "受け取ったIDを使う", header: "req-1", expectSame: true},
+		{name: "ヘッダーがなければ生成する", header: ""},
+		{name: "形式が不正なIDは置き換える", header: "abc */ DROP TABLE users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+			e := echo.New()
+			e.Use(middleware.RequestIDMiddleware())
+			e.Use(middleware.ErrorHandlerMiddleware())
+			e.GET("/users/:id", func(c echo.Context) error {
+				fromContext = requestid.FromContext(c.Request().Context())
+				return domainerror.NewNotFoundError("User", c.Param("id"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/u1", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(requestid.Header)
+			assert.True(t, requestid.Valid(id))
+			assert.Equal(t, id, fromContext)
+			if tt.expectSame {
+				assert.Equal(t, tt.header, id)
+			} else {
+				assert.NotEqual(t, tt.header, id)
+			}
+
+			// エラーレスポンスにも同じIDを含める
+			var response middleware.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, id, response.RequestID)
+		})
+	}
+}
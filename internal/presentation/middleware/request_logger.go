@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/logging"
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+// RequestLoggerMiddleware はリクエストごとのロガーをコンテキストに格納し、完了時にアクセスログを出力します
+// ロガーにはリクエストID、メソッド、ルートが付与されるため、処理中のログもリクエストと対応付けられます
+// RequestIDMiddlewareより内側、最終的なステータスを記録するためErrorHandlerMiddlewareより外側で使います
+func RequestLoggerMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			attrs := []any{
+				slog.String("request_id", requestid.FromContext(req.Context())),
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+			}
+			// TracingMiddlewareより内側で使う場合は、ログとトレースを対応付けるためトレースIDも付与する
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+				attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+			}
+			requestLogger := logger.With(attrs...)
+			ctx := logging.WithLogger(req.Context(), requestLogger)
+			c.SetRequest(req.WithContext(ctx))
+
+			// エラーはErrorHandlerMiddlewareがレスポンスに変換済みのため、確定したステータスを記録する
+			err := next(c)
+
+			status := c.Response().Status
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			access := []slog.Attr{
+				slog.String("path", req.URL.Path),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int64("bytes_out", c.Response().Size),
+				slog.String("remote_ip", c.RealIP()),
+			}
+			if handled, ok := c.Get(handledErrorKey).(error); ok {
+				access = append(access, slog.String("error", handled.Error()))
+			}
+			logging.FromContext(ctx).LogAttrs(ctx, level, "リクエストを処理しました", access...)
+			return err
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/logging"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+)
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	dbErr := domainerror.NewDatabaseError(domainerror.ErrConnection, "select", "users", errors.New("connection refused"))
+
+	tests := []struct {
+		name           string
+		handler        echo.HandlerFunc
+		expectedStatus int
+		expectedLevels []string
+		expectedAttrs  map[string]any
+		expectedKinds  []any
+	}{
+		{
+			name: "成功: アクセスログにリクエストの情報と追加した属性を含む",
+			handler: func(c echo.Context) error {
+				logging.AddAttrs(c.Request().Context(), slog.String("user_id", c.Param("id")))
+				return c.NoContent(http.StatusNoContent)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedLevels: []string{"INFO"},
+			expectedAttrs:  map[string]any{"user_id": "u1"},
+		},
+		{
+			name: "クライアントエラーはWARNで記録する",
+			handler: func(_ echo.Context) error {
+				return domainerror.NewNotFoundError("User", "u1")
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedLevels: []string{"WARN"},
+			expectedAttrs:  map[string]any{"error": "User (ID: u1) エンティティが見つかりません"},
+		},
+		{
+			name: "サーバーエラーはエラーの連鎖と分類を記録する",
+			handler: func(_ echo.Context) error {
+				return fmt.Errorf("ユーザーの取得に失敗しました: %w", dbErr)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedLevels: []string{"ERROR", "ERROR"},
+			expectedKinds:  []any{"database", "connection"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := logging.New(&buf, logging.Config{Format: logging.FormatJSON})
+			require.NoError(t, err)
+
+			e := echo.New()
+			e.Use(middleware.RequestIDMiddleware())
+			e.Use(middleware.RequestLoggerMiddleware(logger))
+			e.Use(middleware.ErrorHandlerMiddleware())
+			e.GET("/users/:id", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/u1", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.Len(t, lines, len(tt.expectedLevels))
+			records := make([]map[string]any, len(lines))
+			for i, line := range lines {
+				require.NoError(t, json.Unmarshal([]byte(line), &records[i]))
+				assert.Equal(t, tt.expectedLevels[i], records[i]["level"])
+				assert.Equal(t, "req-1", records[i]["request_id"])
+				assert.Equal(t, "/users/:id", records[i]["route"])
+			}
+
+			// 最後の行がアクセスログ
+			access := records[len(records)-1]
+			assert.EqualValues(t, tt.expectedStatus, access["status"])
+			assert.Contains(t, access, "latency")
+			for k, v := range tt.expectedAttrs {
+				assert.Equal(t, v, access[k])
+			}
+
+			if tt.expectedKinds != nil {
+				errorLog := records[0]
+				assert.Equal(t, "internal_server_error", errorLog["error_code"])
+				assert.Equal(t, tt.expectedKinds, errorLog["error_kinds"])
+				chain, ok := errorLog["error_chain"].([]any)
+				require.True(t, ok)
+				// fmtのラップ、DatabaseError、元のエラーの3段
+				assert.Len(t, chain, 3)
+				assert.Contains(t, chain[1], "*domainerror.DatabaseError")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/nansystem/go-ddd/internal/presentation/middleware"
+
+// TracingMiddleware はルートごとにサーバースパンを記録します
+// 受信したtraceparentヘッダーを親として引き継ぎ、スパンはリクエストのコンテキストに格納します
+// 最終的なステータスを記録するため、ErrorHandlerMiddlewareより外側で使います
+func TracingMiddleware() echo.MiddlewareFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := c.Path()
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			// エラーはErrorHandlerMiddlewareがレスポンスに変換済みのため、確定したステータスと変換したエラーを記録する
+			err := next(c)
+			if handled, ok := c.Get(handledErrorKey).(error); ok {
+				span.RecordError(handled)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name           string
+		traceparent    string
+		handlerErr     error
+		expectedStatus codes.Code
+	}{
+		{name: "受け取ったtraceparentを親にする", traceparent: traceparent},
+		{name: "traceparentがなければ新しいトレースを開始する"},
+		{name: "サーバーエラーはスパンをエラーにする", handlerErr: domainerror.ErrDatabase, expectedStatus: codes.Error},
+		{name: "クライアントエラーはスパンをエラーにしない", handlerErr: domainerror.NewNotFoundError("User", "u1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := setupTracing(t)
+
+			var handlerSpan trace.SpanContext
+			e := echo.New()
+			e.Use(middleware.TracingMiddleware())
+			e.Use(middleware.ErrorHandlerMiddleware())
+			e.GET("/users/:id", func(c echo.Context) error {
+				handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+				return tt.handlerErr
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/u1", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "GET /users/:id", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID(), "ハンドラーのコンテキストにスパンが格納される")
+			assert.Equal(t, tt.expectedStatus, span.Status().Code)
+			// ErrorHandlerMiddlewareがレスポンスに変換したエラーも記録する
+			var events []string
+			for _, ev := range span.Events() {
+				events = append(events, ev.Name)
+			}
+			if tt.handlerErr != nil {
+				assert.Equal(t, []string{"exception"}, events)
+			} else {
+				assert.Empty(t, events)
+			}
+			if tt.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+				assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+		})
+	}
+}
+
+// setupTracing はテスト中のみ記録用のTracerProviderとW3Cのプロパゲーターをグローバルに設定します
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
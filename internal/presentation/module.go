@@ -0,0 +1,165 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Module はルーティングと起動・停止処理をまとめた機能単位です
+// 境界づけられたコンテキストを追加する場合は、Moduleを実装してレジストリに登録します
+type Module interface {
+	// Name はモジュールの一意な名前です
+	Name() string
+	// Dependencies は先に初期化されている必要があるモジュールの名前です
+	Dependencies() []string
+	// RegisterRoutes はルートグループにエンドポイントを登録します
+	RegisterRoutes(g *echo.Group)
+}
+
+// Starter はバックグラウンド処理などの起動が必要なモジュールが実装します
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper は停止処理が必要なモジュールが実装します
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// RouteObserver はすべてのモジュールのルートを登録した後に、登録されたルートを参照するモジュールが実装します
+// (APIドキュメントの生成など)
+type RouteObserver interface {
+	RoutesRegistered(routes []*echo.Route) error
+}
+
+// ModuleRegistry はモジュールを依存関係の順に初期化します
+type ModuleRegistry struct {
+	modules []Module
+	ordered []Module
+	started []Module
+}
+
+// NewModuleRegistry は新しいModuleRegistryを作成します
+func NewModuleRegistry(modules ...Module) *ModuleRegistry {
+	return &ModuleRegistry{modules: modules}
+}
+
+// Register はモジュールを追加します
+func (r *ModuleRegistry) Register(m Module) {
+	r.modules = append(r.modules, m)
+}
+
+// Build は依存関係を解決し、依存先から順にルートを登録します
+// その後、RouteObserverを実装するモジュールに登録されたすべてのルートを渡します
+// 未登録の依存先や循環依存がある場合、RouteObserverが失敗した場合はエラーを返します
+func (r *ModuleRegistry) Build(e *echo.Echo) error {
+	ordered, err := sortModules(r.modules)
+	if err != nil {
+		return err
+	}
+	r.ordered = ordered
+
+	root := e.Group("")
+	for _, m := range ordered {
+		m.RegisterRoutes(root)
+	}
+	for _, m := range ordered {
+		o, ok := m.(RouteObserver)
+		if !ok {
+			continue
+		}
+		if err := o.RoutesRegistered(e.Routes()); err != nil {
+			return fmt.Errorf("モジュール %s の初期化に失敗しました: %w", m.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Start はStarterを実装するモジュールを依存先から順に起動します
+// 起動に失敗した場合は、それまでに起動したモジュールを停止してからエラーを返します
+func (r *ModuleRegistry) Start(ctx context.Context) error {
+	for _, m := range r.ordered {
+		s, ok := m.(Starter)
+		if !ok {
+			continue
+		}
+		if err := s.Start(ctx); err != nil {
+			return errors.Join(fmt.Errorf("モジュール %s の起動に失敗しました: %w", m.Name(), err), r.Stop(ctx))
+		}
+		r.started = append(r.started, m)
+	}
+	return nil
+}
+
+// Stop は起動済みのモジュールを起動と逆の順に停止します
+func (r *ModuleRegistry) Stop(ctx context.Context) error {
+	var errs []error
+	for i := len(r.started) - 1; i >= 0; i-- {
+		m := r.started[i]
+		s, ok := m.(Stopper)
+		if !ok {
+			continue
+		}
+		if err := s.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("モジュール %s の停止に失敗しました: %w", m.Name(), err))
+		}
+	}
+	r.started = nil
+	return errors.Join(errs...)
+}
+
+// sortModules は依存先が先に来るようにモジュールを並べ替えます
+// 依存関係のないモジュール同士は登録順を保ちます
+func sortModules(modules []Module) ([]Module, error) {
+	byName := make(map[string]Module, len(modules))
+	for _, m := range modules {
+		if _, ok := byName[m.Name()]; ok {
+			return nil, fmt.Errorf("モジュール名が重複しています: %s", m.Name())
+		}
+		byName[m.Name()] = m
+	}
+	for _, m := range modules {
+		for _, dep := range m.Dependencies() {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("モジュール %s の依存先 %s が登録されていません", m.Name(), dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(modules))
+	ordered := make([]Module, 0, len(modules))
+
+	var visit func(m Module, path []string) error
+	visit = func(m Module, path []string) error {
+		switch state[m.Name()] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("モジュールが循環依存しています: %v", append(path, m.Name()))
+		}
+		state[m.Name()] = visiting
+		for _, dep := range m.Dependencies() {
+			if err := visit(byName[dep], append(path, m.Name())); err != nil {
+				return err
+			}
+		}
+		state[m.Name()] = visited
+		ordered = append(ordered, m)
+		return nil
+	}
+
+	for _, m := range modules {
+		if err := visit(m, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
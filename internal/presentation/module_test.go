@@ -0,0 +1,164 @@
+package presentation_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/presentation"
+)
+
+// fakeModule は呼び出し順を記録するテスト用のモジュールです
+type fakeModule struct {
+	name     string
+	deps     []string
+	events   *[]string
+	startErr error
+}
+
+func (m *fakeModule) Name() string           { return m.name }
+func (m *fakeModule) Dependencies() []string { return m.deps }
+
+func (m *fakeModule) RegisterRoutes(g *echo.Group) {
+	*m.events = append(*m.events, "routes:"+m.name)
+	g.GET("/"+m.name, func(c echo.Context) error { return c.String(http.StatusOK, m.name) })
+}
+
+func (m *fakeModule) Start(_ context.Context) error {
+	*m.events = append(*m.events, "start:"+m.name)
+	return m.startErr
+}
+
+func (m *fakeModule) Stop(_ context.Context) error {
+	*m.events = append(*m.events, "stop:"+m.name)
+	return nil
+}
+
+func TestModuleRegistry(t *testing.T) {
+	var events []string
+	registry := presentation.NewModuleRegistry(
+		&fakeModule{name: "order", deps: []string{"user", "catalog"}, events: &events},
+		&fakeModule{name: "user", events: &events},
+		&fakeModule{name: "catalog", deps: []string{"user"}, events: &events},
+	)
+
+	e := echo.New()
+	require.NoError(t, registry.Build(e))
+	require.NoError(t, registry.Start(context.Background()))
+	require.NoError(t, registry.Stop(context.Background()))
+
+	assert.Equal(t, []string{
+		"routes:user", "routes:catalog", "routes:order",
+		"start:user", "start:catalog", "start:order",
+		"stop:order", "stop:catalog", "stop:user",
+	}, events)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/order", nil))
+	assert.Equal(t, "order", rec.Body.String())
+}
+
+// observerModule は登録されたルートを記録するテスト用のモジュールです
+type observerModule struct {
+	fakeModule
+	routes []string
+	err    error
+}
+
+func (m *observerModule) RoutesRegistered(routes []*echo.Route) error {
+	*m.events = append(*m.events, "observe:"+m.name)
+	for _, r := range routes {
+		m.routes = append(m.routes, r.Method+" "+r.Path)
+	}
+	return m.err
+}
+
+func TestModuleRegistry_RouteObserver(t *testing.T) {
+	errObserve := errors.New("observe failed")
+
+	tests := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{name: "すべてのモジュールのルートを受け取る"},
+		{name: "失敗した場合はBuildがエラーを返す", err: errObserve, expectedErr: errObserve},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			// 依存関係がなく先に登録されるモジュールも、後のモジュールのルートを受け取れる
+			observer := &observerModule{fakeModule: fakeModule{name: "docs", events: &events}, err: tt.err}
+			registry := presentation.NewModuleRegistry(observer, &fakeModule{name: "user", events: &events})
+
+			err := registry.Build(echo.New())
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, []string{"routes:docs", "routes:user", "observe:docs"}, events)
+			assert.ElementsMatch(t, []string{"GET /docs", "GET /user"}, observer.routes)
+		})
+	}
+}
+
+func TestModuleRegistry_StartFailure(t *testing.T) {
+	var events []string
+	errStart := errors.New("start failed")
+	registry := presentation.NewModuleRegistry(
+		&fakeModule{name: "user", events: &events},
+		&fakeModule{name: "order", deps: []string{"user"}, events: &events, startErr: errStart},
+	)
+
+	require.NoError(t, registry.Build(echo.New()))
+	err := registry.Start(context.Background())
+
+	assert.ErrorIs(t, err, errStart)
+	// 起動済みのモジュールは停止されること
+	assert.Equal(t, []string{"routes:user", "routes:order", "start:user", "start:order", "stop:user"}, events)
+}
+
+func TestModuleRegistry_BuildErrors(t *testing.T) {
+	var events []string
+	tests := []struct {
+		name    string
+		modules []presentation.Module
+	}{
+		{
+			name: "未登録の依存先",
+			modules: []presentation.Module{
+				&fakeModule{name: "order", deps: []string{"user"}, events: &events},
+			},
+		},
+		{
+			name: "循環依存",
+			modules: []presentation.Module{
+				&fakeModule{name: "a", deps: []string{"b"}, events: &events},
+				&fakeModule{name: "b", deps: []string{"a"}, events: &events},
+			},
+		},
+		{
+			name: "名前の重複",
+			modules: []presentation.Module{
+				&fakeModule{name: "user", events: &events},
+				&fakeModule{name: "user", events: &events},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := presentation.NewModuleRegistry(tt.modules...).Build(echo.New())
+			assert.Error(t, err)
+		})
+	}
+}
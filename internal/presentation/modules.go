@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/health"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+// HealthModule はヘルスチェックのエンドポイントを提供します
+type HealthModule struct {
+	handler *HealthHandler
+}
+
+func NewHealthModule(registry *health.Registry) *HealthModule {
+	return &HealthModule{handler: NewHealthHandler(registry)}
+}
+
+func (m *HealthModule) Name() string { return "health" }
+
+func (m *HealthModule) Dependencies() []string { return nil }
+
+func (m *HealthModule) RegisterRoutes(g *echo.Group) {
+	m.handler.SetupHealthRoutes(g)
+}
+
+// UserModule はユーザー管理のエンドポイントを提供します
+type UserModule struct {
+	handler *UserHandler
+}
+
+func NewUserModule(userService usecase.UserServiceInterface) *UserModule {
+	return &UserModule{handler: NewUserHandler(userService)}
+}
+
+func (m *UserModule) Name() string { return "user" }
+
+func (m *UserModule) Dependencies() []string { return nil }
+
+func (m *UserModule) RegisterRoutes(g *echo.Group) {
+	m.handler.SetupUserRoutes(g.Group("/users"))
+}
+
+// OpenAPIModule はAPIドキュメント (/openapi.json) とビューアー (/docs) を提供します
+type OpenAPIModule struct {
+	handler *OpenAPIHandler
+}
+
+func NewOpenAPIModule() *OpenAPIModule {
+	return &OpenAPIModule{handler: NewOpenAPIHandler()}
+}
+
+func (m *OpenAPIModule) Name() string { return "openapi" }
+
+func (m *OpenAPIModule) Dependencies() []string { return nil }
+
+func (m *OpenAPIModule) RegisterRoutes(g *echo.Group) {
+	m.handler.SetupOpenAPIRoutes(g)
+}
+
+// RoutesRegistered はすべてのモジュールが登録したルートからAPIドキュメントを生成します
+func (m *OpenAPIModule) RoutesRegistered(routes []*echo.Route) error {
+	return m.handler.Load(routes)
+}
@@ -0,0 +1,316 @@
+package presentation
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/openapi"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+)
+
+// APIドキュメントを公開するパス
+const (
+	OpenAPIPath = "/openapi.json"
+	DocsPath    = "/docs"
+)
+
+// apiVersion はAPIのバージョンです (メジャーバージョンはレスポンスの契約 testdata/contract/v1 に対応する)
+const apiVersion = "1.0.0"
+
+// operationDoc は操作ごとのAPI仕様です
+type operationDoc struct {
+	summary     string
+	description string
+	query       []openapi.Parameter
+	// request はリクエストボディの型です (ボディがない場合はnil)
+	request     any
+	requestType string
+	status      int
+	// response はレスポンスボディの型です (ボディがない場合はnil)
+	response any
+	headers  map[string]openapi.Header
+	// errors は返しうるエラーコードです (commonErrorsは含めなくてよい)
+	errors []string
+}
+
+// commonErrors はすべての操作で返しうるエラーコードです
+var commonErrors = []string{middleware.CodeTimeout, middleware.CodeInternalServerError}
+
+// bodyErrors はリクエストボディを受け取る操作で返しうるエラーコードです
+var bodyErrors = []string{
+	middleware.CodeInvalidInput, middleware.CodeBadRequest, middleware.CodeRequestTooLarge, middleware.CodeUnsupportedMediaType,
+}
+
+// userOperations はoperationIdごとのAPI仕様です
+// SetupUserRoutesがルートの名前 (echo.Route.Name) にoperationIdを付けるため、登録されたルートとこの名前で対応づけます
+var userOperations = map[string]operationDoc{
+	"listUsers": {
+		summary: "ユーザー一覧を取得します",
+		description: "キーセットページネーションで取得します。次のページはレスポンスのnext_cursorをcursorに指定して取得します。" +
+			"sortを省略した場合はカーソル発行時の並び順を引き継ぎます。",
+		query: []openapi.Parameter{
+			{Name: "limit", In: "query", Description: "1ページの件数",
+				Schema: &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: openapi.Int(1), Maximum: openapi.Int(user.MaxLimit), Default: user.DefaultLimit}},
+			{Name: "cursor", In: "query", Description: "前のページのレスポンスのnext_cursor",
+				Schema: &openapi.Schema{Type: openapi.Types{"string"}}},
+			{Name: "sort", In: "query", Description: "並び順 (-を付けると降順)",
+				Schema: &openapi.Schema{Type: openapi.Types{"string"}, Enum: sortValues(), Default: string(user.SortByCreatedAt)}},
+			{Name: "name_prefix", In: "query", Description: "名前の前方一致条件",
+				Schema: &openapi.Schema{Type: openapi.Types{"string"}}},
+			{Name: "created_from", In: "query", Description: "作成日時の下限 (この日時を含む)",
+				Schema: &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}},
+			{Name: "created_to", In: "query", Description: "作成日時の上限 (この日時を含まない)",
+				Schema: &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}},
+		},
+		status:   http.StatusOK,
+		response: UserListResponse{},
+		errors:   []string{middleware.CodeInvalidInput},
+	},
+	"getUser": {
+		summary:  "ユーザーを取得します",
+		status:   http.StatusOK,
+		response: UserResponse{},
+		errors:   []string{middleware.CodeNotFound},
+	},
+	"createUser": {
+		summary:     "ユーザーを作成します",
+		description: "IDはサーバーで採番するため指定できません。",
+		request:     CreateUserRequest{},
+		status:      http.StatusCreated,
+		response:    CreateUserResponse{},
+		headers: map[string]openapi.Header{
+			echo.HeaderLocation: {Description: "作成したユーザーのURI", Schema: &openapi.Schema{Type: openapi.Types{"string"}}},
+		},
+		errors: slices.Concat(bodyErrors, []string{middleware.CodeDuplicateEmail, middleware.CodeDuplicateEntry}),
+	},
+	"updateUser": {
+		summary:  "ユーザーの全項目を更新します",
+		request:  UpdateUserRequest{},
+		status:   http.StatusOK,
+		response: UserResponse{},
+		errors:   slices.Concat(bodyErrors, []string{middleware.CodeNotFound, middleware.CodeDuplicateEmail}),
+	},
+	"patchUser": {
+		summary:     "ユーザーを部分更新します",
+		description: "JSON Merge Patch (RFC 7396) で指定した項目だけを更新します。必須項目はnullで削除できません。",
+		request:     PatchUserRequest{},
+		requestType: MIMEApplicationMergePatchJSON,
+		status:      http.StatusOK,
+		response:    UserResponse{},
+		errors:      slices.Concat(bodyErrors, []string{middleware.CodeNotFound, middleware.CodeDuplicateEmail}),
+	},
+	"deleteUser": {
+		summary: "ユーザーを削除します",
+		status:  http.StatusNoContent,
+		errors:  []string{middleware.CodeNotFound},
+	},
+}
+
+// sortValues は一覧の並び順に指定できる値です
+func sortValues() []string {
+	var values []string
+	for _, f := range []user.SortField{user.SortByName, user.SortByCreatedAt} {
+		values = append(values, string(f), "-"+string(f))
+	}
+	return values
+}
+
+// NewOpenAPIDocument はEchoに登録されたルートからOpenAPIドキュメントを生成します
+// 名前がoperationIdのルートを仕様と対応づけ、それ以外のルート (/metricsなど) は記述しません
+// 仕様に対応するルートがない場合や、同じoperationIdのルートが複数ある場合はエラーを返します
+func NewOpenAPIDocument(routes []*echo.Route) (*openapi.Document, error) {
+	return buildOpenAPIDocument(routes, "users", userOperations)
+}
+
+// buildOpenAPIDocument はルートと操作ごとの仕様を突き合わせてドキュメントを組み立てます
+func buildOpenAPIDocument(routes []*echo.Route, tag string, ops map[string]operationDoc) (*openapi.Document, error) {
+	doc := openapi.New(openapi.Info{
+		Title:   "go-ddd API",
+		Version: apiVersion,
+		Description: "エラーレスポンスはAcceptでapplication/problem+jsonを要求した場合 (またはERROR_FORMAT=problemの場合) にRFC 9457形式になります。" +
+			"メッセージはAccept-Languageに応じて翻訳されます。",
+	})
+
+	// echo.Routesの順序は不定のため、エラーが毎回同じになるようパスとメソッドの順に処理する
+	routes = slices.Clone(routes)
+	slices.SortFunc(routes, func(a, b *echo.Route) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Method, b.Method))
+	})
+	byName := map[string][]*echo.Route{}
+	for _, r := range routes {
+		if _, ok := ops[r.Name]; ok {
+			byName[r.Name] = append(byName[r.Name], r)
+		}
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(ops)) {
+		matched := byName[id]
+		if len(matched) == 0 {
+			errs = append(errs, fmt.Errorf("仕様 %s に対応するルートがありません", id))
+			continue
+		}
+		if len(matched) > 1 {
+			var names []string
+			for _, r := range matched {
+				names = append(names, r.Method+" "+r.Path)
+			}
+			errs = append(errs, fmt.Errorf("operationId %s のルートが複数あります (%s)", id, strings.Join(names, ", ")))
+			continue
+		}
+
+		r, op := matched[0], ops[id]
+		path, params := openapi.EchoPath(r.Path)
+		operation, err := op.build(doc, id, tag, params)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ルート %s %s: %w", r.Method, r.Path, err))
+			continue
+		}
+		if err := doc.AddOperation(r.Method, path, operation); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return doc, errors.Join(errs...)
+}
+
+// build はOpenAPIの操作を組み立てます
+// ErrorCatalogにステータスが定義されていないエラーコードがある場合はエラーを返します
+func (o operationDoc) build(doc *openapi.Document, id, tag string, pathParams []string) (*openapi.Operation, error) {
+	op := &openapi.Operation{
+		OperationID: id,
+		Summary:     o.summary,
+		Description: o.description,
+		Tags:        []string{tag},
+		Responses:   map[string]*openapi.Response{},
+	}
+	for _, p := range pathParams {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name: p, In: "path", Required: true, Schema: &openapi.Schema{Type: openapi.Types{"string"}},
+		})
+	}
+	op.Parameters = append(op.Parameters, o.query...)
+
+	if o.request != nil {
+		// 未知のフィールドは拒否する (decodeJSON)
+		schema, err := doc.ClosedSchemaOf(o.request)
+		if err != nil {
+			return nil, err
+		}
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				cmp.Or(o.requestType, echo.MIMEApplicationJSON): {Schema: schema},
+			},
+		}
+	}
+
+	res := &openapi.Response{Description: http.StatusText(o.status), Headers: o.headers}
+	if o.response != nil {
+		schema, err := doc.SchemaOf(o.response)
+		if err != nil {
+			return nil, err
+		}
+		res.Content = map[string]openapi.MediaType{echo.MIMEApplicationJSON: {Schema: schema}}
+	}
+	op.Responses[strconv.Itoa(o.status)] = res
+
+	byStatus := map[int][]string{}
+	for _, code := range slices.Concat(o.errors, commonErrors) {
+		entry, ok := middleware.ErrorCatalog[code]
+		if !ok || entry.Status == 0 {
+			return nil, fmt.Errorf("エラーコード %s のステータスがErrorCatalogにありません", code)
+		}
+		byStatus[entry.Status] = append(byStatus[entry.Status], code)
+	}
+	for status, codes := range byStatus {
+		res, err := errorResponse(doc, status, codes)
+		if err != nil {
+			return nil, err
+		}
+		op.Responses[strconv.Itoa(status)] = res
+	}
+	return op, nil
+}
+
+// errorResponse はステータスごとのエラーレスポンスです
+// 形式はErrorHandlerMiddlewareと同じく、Acceptに応じて従来の形式とRFC 9457形式を返します
+func errorResponse(doc *openapi.Document, status int, codes []string) (*openapi.Response, error) {
+	slices.Sort(codes)
+	codes = slices.Compact(codes)
+	withCodes := func(base *openapi.Schema, properties ...string) *openapi.Schema {
+		s := &openapi.Schema{Properties: map[string]*openapi.Schema{}}
+		for _, p := range properties {
+			s.Properties[p] = &openapi.Schema{Type: openapi.Types{"string"}, Enum: codes}
+		}
+		return &openapi.Schema{AllOf: []*openapi.Schema{base, s}}
+	}
+	legacy, err := doc.SchemaOf(middleware.ErrorResponse{})
+	if err != nil {
+		return nil, err
+	}
+	problem, err := doc.SchemaOf(middleware.Problem{})
+	if err != nil {
+		return nil, err
+	}
+	return &openapi.Response{
+		Description: http.StatusText(status),
+		Content: map[string]openapi.MediaType{
+			echo.MIMEApplicationJSON:      {Schema: withCodes(legacy, "error", "code")},
+			middleware.ProblemContentType: {Schema: withCodes(problem, "code")},
+		},
+	}, nil
+}
+
+// OpenAPIHandler はAPIドキュメントとビューアーを返します
+// ドキュメントはすべてのルートを登録した後にLoadで生成します
+type OpenAPIHandler struct {
+	spec   []byte
+	viewer []byte
+}
+
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{}
+}
+
+// Load はEchoに登録されたルートからドキュメントを生成します
+func (h *OpenAPIHandler) Load(routes []*echo.Route) error {
+	doc, err := NewOpenAPIDocument(routes)
+	if err != nil {
+		return fmt.Errorf("OpenAPIドキュメントの生成に失敗しました: %w", err)
+	}
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	viewer, err := openapi.Viewer(doc.Info.Title, OpenAPIPath)
+	if err != nil {
+		return err
+	}
+	h.spec, h.viewer = spec, viewer
+	return nil
+}
+
+// Spec はOpenAPIドキュメントを返します
+func (h *OpenAPIHandler) Spec(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, h.spec)
+}
+
+// Viewer はドキュメントを表示するHTMLを返します
+func (h *OpenAPIHandler) Viewer(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, h.viewer)
+}
+
+func (h *OpenAPIHandler) SetupOpenAPIRoutes(g *echo.Group) {
+	g.GET(OpenAPIPath, h.Spec)
+	g.GET(DocsPath, h.Viewer)
+}
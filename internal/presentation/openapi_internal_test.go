@@ -0,0 +1,44 @@
+package presentation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/openapi"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+)
+
+func TestOperationDoc_Build_UnknownErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		errMsg string
+	}{
+		{name: "ErrorCatalogにないエラーコード", code: "no_such_code", errMsg: "エラーコード no_such_code"},
+		{name: "ステータスが固定されていないエラーコード", code: middleware.CodeHTTPError, errMsg: "エラーコード http_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := operationDoc{status: http.StatusOK, errors: []string{tt.code}}
+			_, err := o.build(openapi.New(openapi.Info{}), "getUser", "users", nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestOperationDoc_Build_InvalidSchema(t *testing.T) {
+	o := operationDoc{
+		status: http.StatusCreated,
+		request: struct {
+			Name string `json:"name" openapi:"maxLength=many"`
+		}{},
+	}
+	_, err := o.build(openapi.New(openapi.Info{}), "createUser", "users", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `maxLength の値 "many" は整数ではありません`)
+}
@@ -0,0 +1,425 @@
+package presentation_test
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/openapi"
+	"github.com/nansystem/go-ddd/internal/presentation"
+	"github.com/nansystem/go-ddd/internal/presentation/middleware"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	e := echo.New()
+	presentation.NewUserModule(new(usecase.MockUserService)).RegisterRoutes(e.Group(""))
+	doc, err := presentation.NewOpenAPIDocument(e.Routes())
+	require.NoError(t, err)
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	// 登録されたすべてのルートに操作がある
+	operations := 0
+	for _, item := range doc.Paths {
+		operations += len(item)
+	}
+	assert.Equal(t, len(e.Routes()), operations)
+	for _, r := range e.Routes() {
+		path, _ := openapi.EchoPath(r.Path)
+		assert.NotNil(t, doc.Operation(r.Method, path), "%s %s", r.Method, r.Path)
+	}
+
+	// タグで指定した制約がドメインの検証規則と一致する
+	for _, name := range []string{"CreateUserRequest", "UpdateUserRequest", "PatchUserRequest"} {
+		s := doc.Components.Schemas[name]
+		require.NotNil(t, s, name)
+		assert.Equal(t, user.MaxNameLength, *s.Properties["name"].MaxLength, name)
+		// 空白のみの名前は拒否するため、空白以外の文字を含むことを必須にする
+		assert.Equal(t, `\S`, s.Properties["name"].Pattern, name)
+		assert.Nil(t, s.Properties["name"].MinLength, name)
+	}
+	// IDはサーバーで採番するため、リクエストの項目として記述しない
+	assert.NotContains(t, doc.Components.Schemas["CreateUserRequest"].Properties, "id")
+}
+
+func TestNewOpenAPIDocument_Routes(t *testing.T) {
+	noop := func(echo.Context) error { return nil }
+
+	tests := []struct {
+		name          string
+		setup         func(e *echo.Echo)
+		expectedPaths []string
+		expectedErr   string
+	}{
+		{
+			name: "実際に登録したパスで記述し、仕様のないルートは含めない",
+			setup: func(e *echo.Echo) {
+				e.GET("/metrics", noop)
+				presentation.NewUserModule(nil).RegisterRoutes(e.Group("/v1"))
+			},
+			expectedPaths: []string{"/v1/users", "/v1/users/{id}"},
+		},
+		{
+			name: "仕様に対応するルートがなければエラー",
+			setup: func(e *echo.Echo) {
+				e.GET("/users", noop).Name = "listUsers"
+			},
+			expectedErr: "仕様 getUser に対応するルートがありません",
+		},
+		{
+			name: "同じoperationIdのルートが複数あればエラー",
+			setup: func(e *echo.Echo) {
+				presentation.NewUserModule(nil).RegisterRoutes(e.Group(""))
+				e.GET("/members", noop).Name = "listUsers"
+			},
+			expectedErr: "operationId listUsers のルートが複数あります (GET /members, GET /users)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			tt.setup(e)
+
+			doc, err := presentation.NewOpenAPIDocument(e.Routes())
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			var paths []string
+			for path := range doc.Paths {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+// TestOpenAPIDocument_MatchesHandlers はハンドラーの実際のレスポンスがドキュメントの記述と一致することを検証します
+// ステータスコード、Content-Type、ボディのスキーマのいずれかが食い違った場合に失敗します
+// ドキュメントに記述のないプロパティを返した場合も失敗とします
+func TestOpenAPIDocument_MatchesHandlers(t *testing.T) {
+	u := &user.User{ID: "1", Name: "テストユーザー1", Email: mustEmail("test1@example.com")}
+	dbErr := domainerror.NewDatabaseError(domainerror.ErrConnection, "delete", "users", errors.New("connection refused"))
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		contentType string
+		problem     bool
+		setupMock   func(mockService *usecase.MockUserService)
+	}{
+		{
+			name:   "一覧",
+			method: http.MethodGet,
+			target: "/users?sort=name&limit=1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				page := &user.Page{Users: []*user.User{u}, NextCursor: &user.Cursor{Sort: user.Sort{Field: user.SortByName}, Key: u.Name, ID: u.ID}}
+				mockService.On("GetUsers", mock.Anything, mock.Anything).Return(page, nil).Once()
+			},
+		},
+		{
+			name:      "一覧: クエリパラメータが不正",
+			method:    http.MethodGet,
+			target:    "/users?limit=abc",
+			setupMock: func(_ *usecase.MockUserService) {},
+		},
+		{
+			name:   "取得",
+			method: http.MethodGet,
+			target: "/users/1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("GetUserByID", mock.Anything, "1").Return(u, nil).Once()
+			},
+		},
+		{
+			name:    "取得: 存在しない (RFC 9457)",
+			method:  http.MethodGet,
+			target:  "/users/notfound",
+			problem: true,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("GetUserByID", mock.Anything, "notfound").Return(nil, domainerror.NewNotFoundError("User", "notfound")).Once()
+			},
+		},
+		{
+			name:   "取得: タイムアウト",
+			method: http.MethodGet,
+			target: "/users/1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("GetUserByID", mock.Anything, "1").Return(nil, context.DeadlineExceeded).Once()
+			},
+		},
+		{
+			name:   "作成",
+			method: http.MethodPost,
+			target: "/users",
+			body:   `{"name":"テストユーザー1","email":"test1@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:      "作成: 未知のフィールド (RFC 9457)",
+			method:    http.MethodPost,
+			target:    "/users",
+			body:      `{"name":"テストユーザー1","email":"test1@example.com","role":"admin"}`,
+			problem:   true,
+			setupMock: func(_ *usecase.MockUserService) {},
+		},
+		{
+			name:      "作成: ボディが上限を超える",
+			method:    http.MethodPost,
+			target:    "/users",
+			body:      `{"name":"` + strings.Repeat("a", presentation.MaxRequestBodyBytes) + `"}`,
+			setupMock: func(_ *usecase.MockUserService) {},
+		},
+		{
+			name:    "作成: メールアドレスの重複 (RFC 9457)",
+			method:  http.MethodPost,
+			target:  "/users",
+			body:    `{"name":"テストユーザー1","email":"test1@example.com"}`,
+			problem: true,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Return(domainerror.NewDuplicateEmailError("test1@example.com")).Once()
+			},
+		},
+		{
+			name:   "更新",
+			method: http.MethodPut,
+			target: "/users/1",
+			body:   `{"name":"テストユーザー1","email":"test1@example.com"}`,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("UpdateUser", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:      "更新: 不正なJSON",
+			method:    http.MethodPut,
+			target:    "/users/1",
+			body:      `{"name":}`,
+			setupMock: func(_ *usecase.MockUserService) {},
+		},
+		{
+			name:        "部分更新",
+			method:      http.MethodPatch,
+			target:      "/users/1",
+			body:        `{"name":"テストユーザー1"}`,
+			contentType: presentation.MIMEApplicationMergePatchJSON,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("PatchUser", mock.Anything, "1", mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			name:        "部分更新: 検証エラー (RFC 9457)",
+			method:      http.MethodPatch,
+			target:      "/users/1",
+			body:        `{"name":null,"email":"invalid"}`,
+			contentType: presentation.MIMEApplicationMergePatchJSON,
+			problem:     true,
+			setupMock:   func(_ *usecase.MockUserService) {},
+		},
+		{
+			name:   "削除",
+			method: http.MethodDelete,
+			target: "/users/1",
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("DeleteUser", mock.Anything, "1").Return(nil).Once()
+			},
+		},
+		{
+			name:    "削除: データベースエラー (RFC 9457)",
+			method:  http.MethodDelete,
+			target:  "/users/1",
+			problem: true,
+			setupMock: func(mockService *usecase.MockUserService) {
+				mockService.On("DeleteUser", mock.Anything, "1").Return(dbErr).Once()
+			},
+		},
+	}
+
+	// テストと同じルーターに登録されたルートからドキュメントを生成する
+	doc, err := presentation.NewOpenAPIDocument(setupTestRouter(presentation.NewUserHandler(nil)).Routes())
+	require.NoError(t, err)
+	exercised := map[*openapi.Operation]bool{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(usecase.MockUserService)
+			tt.setupMock(mockService)
+			e := setupTestRouter(presentation.NewUserHandler(mockService))
+
+			contentType := cmp.Or(tt.contentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, contentType)
+			}
+			if tt.problem {
+				req.Header.Set(echo.HeaderAccept, middleware.ProblemContentType)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			mockService.AssertExpectations(t)
+
+			// リクエストに一致したルートの操作を探す
+			c := e.NewContext(req, nil)
+			e.Router().Find(tt.method, req.URL.Path, c)
+			path, _ := openapi.EchoPath(c.Path())
+			op := doc.Operation(tt.method, path)
+			require.NotNil(t, op, "%s %s の操作がドキュメントにありません", tt.method, path)
+			exercised[op] = true
+
+			if tt.body != "" {
+				require.NotNil(t, op.RequestBody, "リクエストボディがドキュメントにありません")
+				assert.Contains(t, op.RequestBody.Content, contentType, "Content-Type %s がドキュメントにありません", contentType)
+			}
+
+			res, ok := op.Responses[strconv.Itoa(rec.Code)]
+			require.True(t, ok, "ステータス %d がドキュメントにありません: %s", rec.Code, rec.Body.String())
+			if res.Content == nil {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			mediaType, _, err := mime.ParseMediaType(rec.Header().Get(echo.HeaderContentType))
+			require.NoError(t, err)
+			media, ok := res.Content[mediaType]
+			require.True(t, ok, "Content-Type %s がドキュメントにありません", mediaType)
+
+			var body any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Empty(t, validateSchema(doc, media.Schema, body, "$"), rec.Body.String())
+		})
+	}
+
+	// すべての操作を少なくとも1回は検証する
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			assert.True(t, exercised[op], "%s %s のテストケースがありません", strings.ToUpper(method), path)
+		}
+	}
+}
+
+func TestOpenAPIModule(t *testing.T) {
+	e := echo.New()
+	registry := presentation.NewModuleRegistry(presentation.NewUserModule(new(usecase.MockUserService)), presentation.NewOpenAPIModule())
+	require.NoError(t, registry.Build(e))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, presentation.OpenAPIPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+	var spec struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Equal(t, "3.1.0", spec.OpenAPI)
+	assert.Contains(t, spec.Paths, "/users/{id}")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, presentation.DocsPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Body.String(), presentation.OpenAPIPath)
+}
+
+// validateSchema はJSONの値がスキーマに従っているかを検証し、違反を返します
+// ドキュメントとの食い違いを見つけるため、記述のないプロパティも違反とします
+func validateSchema(doc *openapi.Document, s *openapi.Schema, v any, path string) []string {
+	s = mergeAllOf(doc, doc.Resolve(s))
+
+	var violations []string
+	if len(s.Type) > 0 && !s.Type.Has(jsonType(v)) && !(s.Type.Has("number") && jsonType(v) == "integer") {
+		violations = append(violations, fmt.Sprintf("%s: 型 %s は %v ではありません", path, jsonType(v), s.Type))
+	}
+	if str, ok := v.(string); ok && len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+		violations = append(violations, fmt.Sprintf("%s: %q は %v に含まれません", path, str, s.Enum))
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: 必須のプロパティ %s がありません", path, name))
+			}
+		}
+		for name, value := range v {
+			prop, ok := s.Properties[name]
+			if !ok {
+				violations = append(violations, fmt.Sprintf("%s: プロパティ %s はドキュメントにありません", path, name))
+				continue
+			}
+			violations = append(violations, validateSchema(doc, prop, value, path+"."+name)...)
+		}
+	case []any:
+		for i, item := range v {
+			violations = append(violations, validateSchema(doc, s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return violations
+}
+
+// mergeAllOf はallOfのスキーマを1つのスキーマにまとめます
+// 同じプロパティが複数ある場合は、すべての条件を満たす必要があります
+func mergeAllOf(doc *openapi.Document, s *openapi.Schema) *openapi.Schema {
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	merged := &openapi.Schema{Properties: map[string]*openapi.Schema{}}
+	for _, sub := range s.AllOf {
+		sub = mergeAllOf(doc, doc.Resolve(sub))
+		if len(sub.Type) > 0 {
+			merged.Type = sub.Type
+		}
+		merged.Required = append(merged.Required, sub.Required...)
+		for name, prop := range sub.Properties {
+			if existing, ok := merged.Properties[name]; ok {
+				prop = &openapi.Schema{AllOf: []*openapi.Schema{existing, prop}}
+			}
+			merged.Properties[name] = prop
+		}
+	}
+	return merged
+}
+
+// jsonType はencoding/jsonでデコードした値のJSON Schemaの型です
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
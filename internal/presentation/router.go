@@ -3,16 +3,27 @@
 package presentation
 
 import (
+	"log/slog"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/nansystem/go-ddd/internal/metrics"
 	custommiddleware "github.com/nansystem/go-ddd/internal/presentation/middleware"
 )
 
-func NewRouter() *echo.Echo {
+// NewRouter はミドルウェアを設定したEchoを作成します
+// アクセスログとエラーログはloggerに出力し、メトリクスはmに記録して /metrics で公開します
+func NewRouter(logger *slog.Logger, m *metrics.Metrics, errorHandler custommiddleware.ErrorHandlerConfig) *echo.Echo {
 	e := echo.New()
-	e.Use(middleware.Logger())
+	e.HideBanner = true
+	e.HidePort = true
+	e.Use(custommiddleware.RequestIDMiddleware())
+	e.Use(custommiddleware.TracingMiddleware())
+	e.Use(custommiddleware.RequestLoggerMiddleware(logger))
+	e.Use(custommiddleware.MetricsMiddleware(m))
 	e.Use(middleware.Recover())
-	e.Use(custommiddleware.ErrorHandlerMiddleware())
+	e.Use(custommiddleware.ErrorHandlerMiddlewareWithConfig(errorHandler))
+	e.GET("/metrics", echo.WrapHandler(m.Handler()))
 	return e
 }
@@ -0,0 +1,43 @@
+// Package requestid はリクエストIDをコンテキストで受け渡す機能を提供します
+// HTTPのレスポンスやログ、外部API呼び出し、SQLのコメントで同じIDを使い、1つのリクエストの処理を追跡できるようにします
+package requestid
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// Header はリクエストIDを受け渡すHTTPヘッダーです
+const Header = "X-Request-ID"
+
+// MaxLength は受け付けるリクエストIDの最大長です
+const MaxLength = 128
+
+// validPattern はクライアントから受け付けるリクエストIDの形式です
+// ログやSQLのコメントに埋め込むため、区切りや制御文字を含まない文字に限定します
+var validPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+type contextKey struct{}
+
+// New は新しいリクエストIDを生成します
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid はクライアントから受け取ったリクエストIDをそのまま使えるかを判定します
+func Valid(id string) bool {
+	return len(id) <= MaxLength && validPattern.MatchString(id)
+}
+
+// WithID はリクエストIDを格納したコンテキストを返します
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext はコンテキストに格納されたリクエストIDを返します (格納されていない場合は空文字)
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
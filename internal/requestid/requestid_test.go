@@ -0,0 +1,48 @@
+package requestid_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nansystem/go-ddd/internal/requestid"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected bool
+	}{
+		{name: "UUID", id: "0f8fad5b-d9cb-469f-a165-70867728950e", expected: true},
+		{name: "記号を含む", id: "trace:abc_1.2", expected: true},
+		{name: "空", id: "", expected: false},
+		{name: "空白を含む", id: "abc def", expected: false},
+		{name: "コメントの終端を含む", id: "abc*/DROP", expected: false},
+		{name: "改行を含む", id: "abc\ndef", expected: false},
+		{name: "最大長", id: strings.Repeat("a", requestid.MaxLength), expected: true},
+		{name: "最大長を超える", id: strings.Repeat("a", requestid.MaxLength+1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, requestid.Valid(tt.id))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	id := requestid.New()
+
+	assert.True(t, requestid.Valid(id))
+	assert.NotEqual(t, id, requestid.New())
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Empty(t, requestid.FromContext(context.Background()))
+
+	ctx := requestid.WithID(context.Background(), "req-1")
+	assert.Equal(t, "req-1", requestid.FromContext(ctx))
+}
@@ -0,0 +1,118 @@
+// Package telemetry はOpenTelemetryによるトレースの設定を提供します
+// 各層はotel.Tracerでグローバルに設定されたTracerProviderを使ってスパンを記録します
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// エクスポーターの種類
+const (
+	// ExporterOTLP はOTLP/HTTPでコレクターに送信します
+	// 送信先などはOTEL_EXPORTER_OTLP_ENDPOINT等の標準の環境変数で指定します
+	ExporterOTLP = "otlp"
+	// ExporterStdout は標準出力にJSONで出力します
+	ExporterStdout = "stdout"
+	// ExporterFile はファイルにJSONで出力します
+	ExporterFile = "file"
+	// ExporterNone はトレースを出力しません (伝播は行います)
+	ExporterNone = "none"
+)
+
+// Config はトレースの設定です
+type Config struct {
+	ServiceName string
+	// Exporter はスパンの出力先です (otlp, stdout, file, none)
+	Exporter string
+	// FilePath はExporterがfileの場合の出力先です
+	FilePath string
+}
+
+// Setup はグローバルなTracerProviderとW3C Trace Contextのプロパゲーターを設定します
+// 返す関数は未送信のスパンを送信し、エクスポーターを停止します
+func Setup(ctx context.Context, config Config) (func(ctx context.Context) error, error) {
+	// トレースを出力しない場合も、受け取ったtraceparentは外部呼び出しに引き継ぐ
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, closeOutput, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("トレースのリソースの作成に失敗しました: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeErr := closeOutput(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+// newExporter は設定に従ってエクスポーターを作成します
+// ファイルに出力する場合、停止時にファイルを閉じる関数も返します
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	switch config.Exporter {
+	case ExporterNone:
+		return nil, noClose, nil
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("OTLPエクスポーターの作成に失敗しました: %w", err)
+		}
+		return exporter, noClose, nil
+	case ExporterStdout:
+		exporter, err := newWriterExporter(os.Stdout)
+		return exporter, noClose, err
+	case ExporterFile:
+		f, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("トレースの出力ファイルを開けません: %w", err)
+		}
+		exporter, err := newWriterExporter(f)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, f.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("未対応のエクスポーターです: %s", config.Exporter)
+	}
+}
+
+func newWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, fmt.Errorf("エクスポーターの作成に失敗しました: %w", err)
+	}
+	return exporter, nil
+}
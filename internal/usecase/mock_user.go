@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/nansystem/go-ddd/internal/domain/user"
@@ -11,23 +13,41 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) GetUsers() ([]*user.User, error) {
-	args := m.Called()
+func (m *MockUserService) GetUsers(ctx context.Context, query user.Query) (*user.Page, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*user.User), args.Error(1)
+	return args.Get(0).(*user.Page), args.Error(1)
+}
+
+func (m *MockUserService) GetUserByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserService) CreateUser(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserService) UpdateUser(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
 }
 
-func (m *MockUserService) GetUserByID(id string) (*user.User, error) {
-	args := m.Called(id)
+func (m *MockUserService) PatchUser(ctx context.Context, id string, patch *UserPatch) (*user.User, error) {
+	args := m.Called(ctx, id, patch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *MockUserService) CreateUser(user *user.User) error {
-	args := m.Called(user)
+func (m *MockUserService) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/domain/user"
+)
+
+const tracerName = "github.com/nansystem/go-ddd/internal/usecase"
+
+// TracingUserService はUserServiceInterfaceの各メソッドをスパンで囲むデコレーターです
+type TracingUserService struct {
+	next   UserServiceInterface
+	tracer trace.Tracer
+}
+
+// NewTracingUserService はnextの呼び出しをトレースするTracingUserServiceを作成します
+func NewTracingUserService(next UserServiceInterface) *TracingUserService {
+	return &TracingUserService{next: next, tracer: otel.Tracer(tracerName)}
+}
+
+func (s *TracingUserService) GetUsers(ctx context.Context, query user.Query) (page *user.Page, err error) {
+	ctx, span := s.start(ctx, "GetUsers")
+	defer func() { endSpan(span, err) }()
+	return s.next.GetUsers(ctx, query)
+}
+
+func (s *TracingUserService) GetUserByID(ctx context.Context, id string) (u *user.User, err error) {
+	ctx, span := s.start(ctx, "GetUserByID")
+	defer func() { endSpan(span, err) }()
+	return s.next.GetUserByID(ctx, id)
+}
+
+func (s *TracingUserService) CreateUser(ctx context.Context, u *user.User) (err error) {
+	ctx, span := s.start(ctx, "CreateUser")
+	defer func() { endSpan(span, err) }()
+	return s.next.CreateUser(ctx, u)
+}
+
+func (s *TracingUserService) UpdateUser(ctx context.Context, u *user.User) (err error) {
+	ctx, span := s.start(ctx, "UpdateUser")
+	defer func() { endSpan(span, err) }()
+	return s.next.UpdateUser(ctx, u)
+}
+
+func (s *TracingUserService) PatchUser(ctx context.Context, id string, patch *UserPatch) (u *user.User, err error) {
+	ctx, span := s.start(ctx, "PatchUser")
+	defer func() { endSpan(span, err) }()
+	return s.next.PatchUser(ctx, id, patch)
+}
+
+func (s *TracingUserService) DeleteUser(ctx context.Context, id string) (err error) {
+	ctx, span := s.start(ctx, "DeleteUser")
+	defer func() { endSpan(span, err) }()
+	return s.next.DeleteUser(ctx, id)
+}
+
+func (s *TracingUserService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "UserService."+method, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// endSpan はエラーがあればスパンに記録してから終了します
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+func TestTracingUserService(t *testing.T) {
+	u := user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com"))
+	errNotFound := domainerror.NewNotFoundError("User", "u1")
+
+	tests := []struct {
+		name         string
+		setupMock    func(m *usecase.MockUserService, err error)
+		call         func(ctx context.Context, s *usecase.TracingUserService) error
+		expectedSpan string
+	}{
+		{
+			name: "GetUsers",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("GetUsers", mock.Anything, mock.Anything).Return(&user.Page{}, err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				_, err := s.GetUsers(ctx, user.NewQuery())
+				return err
+			},
+			expectedSpan: "UserService.GetUsers",
+		},
+		{
+			name: "GetUserByID",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("GetUserByID", mock.Anything, "u1").Return(u, err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				_, err := s.GetUserByID(ctx, "u1")
+				return err
+			},
+			expectedSpan: "UserService.GetUserByID",
+		},
+		{
+			name: "CreateUser",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("CreateUser", mock.Anything, u).Return(err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				return s.CreateUser(ctx, u)
+			},
+			expectedSpan: "UserService.CreateUser",
+		},
+		{
+			name: "UpdateUser",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("UpdateUser", mock.Anything, u).Return(err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				return s.UpdateUser(ctx, u)
+			},
+			expectedSpan: "UserService.UpdateUser",
+		},
+		{
+			name: "PatchUser",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("PatchUser", mock.Anything, "u1", mock.Anything).Return(u, err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				_, err := s.PatchUser(ctx, "u1", &usecase.UserPatch{})
+				return err
+			},
+			expectedSpan: "UserService.PatchUser",
+		},
+		{
+			name: "DeleteUser",
+			setupMock: func(m *usecase.MockUserService, err error) {
+				m.On("DeleteUser", mock.Anything, "u1").Return(err)
+			},
+			call: func(ctx context.Context, s *usecase.TracingUserService) error {
+				return s.DeleteUser(ctx, "u1")
+			},
+			expectedSpan: "UserService.DeleteUser",
+		},
+	}
+
+	results := []struct {
+		name string
+		err  error
+	}{
+		{name: "成功", err: nil},
+		{name: "失敗", err: errNotFound},
+	}
+
+	for _, tt := range tests {
+		for _, result := range results {
+			expectedErr := result.err
+			t.Run(tt.name+"/"+result.name, func(t *testing.T) {
+				recorder := setupTracer(t)
+				mockService := new(usecase.MockUserService)
+				tt.setupMock(mockService, expectedErr)
+
+				ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+				err := tt.call(ctx, usecase.NewTracingUserService(mockService))
+				parent.End()
+				assert.Equal(t, expectedErr, err)
+				mockService.AssertExpectations(t)
+
+				spans := recorder.Ended()
+				require.Len(t, spans, 2)
+				span := spans[0]
+				assert.Equal(t, tt.expectedSpan, span.Name())
+				assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+				assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+
+				// nextにはスパンを含むコンテキストを渡す
+				nextCtx := mockService.Calls[0].Arguments.Get(0).(context.Context)
+				assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(nextCtx).SpanID())
+
+				if expectedErr == nil {
+					assert.Equal(t, codes.Unset, span.Status().Code)
+					assert.Empty(t, span.Events())
+					return
+				}
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, expectedErr.Error(), span.Status().Description)
+				require.Len(t, span.Events(), 1)
+				assert.Equal(t, "exception", span.Events()[0].Name)
+			})
+		}
+	}
+}
+
+// setupTracer はスパンを記録するTracerProviderをグローバルに設定します
+func setupTracer(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
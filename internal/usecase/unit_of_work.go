@@ -0,0 +1,10 @@
+package usecase
+
+import "context"
+
+// UnitOfWork は複数のリポジトリ操作を1つのトランザクションとして実行します
+// fnに渡されるコンテキストを使って呼び出したリポジトリは同じトランザクションに参加します
+// fnがエラーを返すかpanicした場合はロールバックされます
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -1,31 +1,90 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/nansystem/go-ddd/internal/domain/user"
 )
 
 type UserServiceInterface interface {
-	GetUsers() ([]*user.User, error)
-	GetUserByID(id string) (*user.User, error)
-	CreateUser(user *user.User) error
+	GetUsers(ctx context.Context, query user.Query) (*user.Page, error)
+	GetUserByID(ctx context.Context, id string) (*user.User, error)
+	CreateUser(ctx context.Context, user *user.User) error
+	UpdateUser(ctx context.Context, user *user.User) error
+	PatchUser(ctx context.Context, id string, patch *UserPatch) (*user.User, error)
+	DeleteUser(ctx context.Context, id string) error
+}
+
+// UserPatch はユーザーの部分更新内容です (JSON Merge Patch)
+// nilのフィールドは更新しません
+type UserPatch struct {
+	Name  *string
+	Email *user.Email
 }
 
 type UserService struct {
 	userRepository user.Repository
+	idGenerator    user.IDGenerator
+	unitOfWork     UnitOfWork
+}
+
+func NewUserService(userRepository user.Repository, idGenerator user.IDGenerator, unitOfWork UnitOfWork) *UserService {
+	return &UserService{userRepository: userRepository, idGenerator: idGenerator, unitOfWork: unitOfWork}
+}
+
+func (s *UserService) GetUsers(ctx context.Context, query user.Query) (*user.Page, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return s.userRepository.GetUsers(ctx, query)
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id string) (*user.User, error) {
+	return s.userRepository.GetUserByID(ctx, id)
 }
 
-func NewUserService(userRepository user.Repository) *UserService {
-	return &UserService{userRepository: userRepository}
+// CreateUser はIDを採番してユーザーを作成します
+// 採番したIDは引数のuserに設定されます
+func (s *UserService) CreateUser(ctx context.Context, user *user.User) error {
+	id, err := s.idGenerator.Generate()
+	if err != nil {
+		return err
+	}
+	user.ID = id
+
+	return s.userRepository.CreateUser(ctx, user)
 }
 
-func (s *UserService) GetUsers() ([]*user.User, error) {
-	return s.userRepository.GetUsers()
+func (s *UserService) UpdateUser(ctx context.Context, user *user.User) error {
+	return s.userRepository.UpdateUser(ctx, user)
 }
 
-func (s *UserService) GetUserByID(id string) (*user.User, error) {
-	return s.userRepository.GetUserByID(id)
+// PatchUser は既存ユーザーに部分更新を適用し、更新後のユーザーを返します
+// 読み込みから更新までを1つのトランザクションで行い、読み込んだ行をロックして同時の更新が失われないようにします
+func (s *UserService) PatchUser(ctx context.Context, id string, patch *UserPatch) (*user.User, error) {
+	var u *user.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		u, err = s.userRepository.GetUserByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if patch.Name != nil {
+			u.Name = *patch.Name
+		}
+		if patch.Email != nil {
+			u.Email = *patch.Email
+		}
+
+		return s.userRepository.UpdateUser(ctx, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
-func (s *UserService) CreateUser(user *user.User) error {
-	return s.userRepository.CreateUser(user)
+func (s *UserService) DeleteUser(ctx context.Context, id string) error {
+	return s.userRepository.DeleteUser(ctx, id)
 }
@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nansystem/go-ddd/internal/domain/domainerror"
+	"github.com/nansystem/go-ddd/internal/domain/user"
+	"github.com/nansystem/go-ddd/internal/infrastructure/memory"
+	"github.com/nansystem/go-ddd/internal/usecase"
+)
+
+func TestUserService_PatchUser(t *testing.T) {
+	name := "山田花子"
+	email := user.ReconstructEmail("yamada@example.com")
+	taken := user.ReconstructEmail("taken@example.com")
+
+	tests := []struct {
+		name        string
+		id          string
+		patch       *usecase.UserPatch
+		expected    *user.User
+		expectedErr error
+	}{
+		{
+			name:     "成功: 指定した項目だけを更新する",
+			id:       "u1",
+			patch:    &usecase.UserPatch{Name: &name},
+			expected: user.NewUser("u1", "山田花子", user.ReconstructEmail("tanaka@example.com")),
+		},
+		{
+			name:     "成功: メールアドレスを更新する",
+			id:       "u1",
+			patch:    &usecase.UserPatch{Email: &email},
+			expected: user.NewUser("u1", "田中太郎", email),
+		},
+		{
+			name:        "失敗: 存在しないユーザー",
+			id:          "missing",
+			patch:       &usecase.UserPatch{Name: &name},
+			expectedErr: domainerror.ErrNotFound,
+		},
+		{
+			name:        "失敗: メールアドレスの重複では何も更新しない",
+			id:          "u1",
+			patch:       &usecase.UserPatch{Name: &name, Email: &taken},
+			expectedErr: domainerror.ErrDuplicated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewUserRepository()
+			require.NoError(t, repo.CreateUser(ctx, user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com"))))
+			require.NoError(t, repo.CreateUser(ctx, user.NewUser("u2", "佐藤次郎", taken)))
+			service := usecase.NewUserService(repo, nil, memory.NewTxManager(repo))
+
+			got, err := service.PatchUser(ctx, tt.id, tt.patch)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				stored, err := repo.GetUserByID(ctx, "u1")
+				require.NoError(t, err)
+				assert.Equal(t, "田中太郎", stored.Name)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+			stored, err := repo.GetUserByID(ctx, tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stored)
+		})
+	}
+}
+
+func TestUserService_PatchUser_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewUserRepository()
+	require.NoError(t, repo.CreateUser(ctx, user.NewUser("u1", "田中太郎", user.ReconstructEmail("tanaka@example.com"))))
+	service := usecase.NewUserService(repo, nil, memory.NewTxManager(repo))
+
+	// 名前とメールアドレスを別々のリクエストで同時に更新しても、どちらの更新も失われない
+	name := "山田花子"
+	email := user.ReconstructEmail("yamada@example.com")
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			patch := &usecase.UserPatch{Name: &name}
+			if i%2 == 1 {
+				patch = &usecase.UserPatch{Email: &email}
+			}
+			_, err := service.PatchUser(ctx, "u1", patch)
+			assert.NoError(t, err, fmt.Sprint(i))
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.GetUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, user.NewUser("u1", "山田花子", email), got)
+}